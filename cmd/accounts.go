@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+var (
+	accountAlias    string
+	accountLabel    string
+	accountTimezone string
+	accountTags     []string
+	accountsFormat  string
+)
+
+// accountsCmd represents the accounts command
+var accountsCmd = &cobra.Command{
+	Use:   "accounts",
+	Short: "Manage the accounts of the configuration file.",
+	Long: `The accounts command adds, removes and lists the accounts of the configuration file
+without editing it by hand. The file is updated in place, keeping its comments and
+the other settings, and is created when missing. As it holds passwords, it is only
+readable by its owner once saved.
+
+Besides the username and password, each account can have an alias to select it with
+"--account", a display label, a timezone and default tags. The label, the tags and
+the date in the account's timezone are available to the message templates as
+{{.Label}}, {{.Tags}} and {{.Date}}.`,
+	Annotations: optionalConfig,
+}
+
+// accountsAddCmd represents the accounts add command
+var accountsAddCmd = &cobra.Command{
+	Use:   "add <username>",
+	Short: "Add an account to the configuration file.",
+	Long: `The add command adds an account to the configuration file. The password is asked
+for without echoing it; when the standard input is not a terminal, it is read from
+its first line instead.
+
+Examples:
+- Add an account:
+  tweethub accounts add brand
+
+- Add an account with metadata:
+  tweethub accounts add brand --alias work --label "Brand account" --timezone Europe/Madrid --tag launch --tag news
+
+- Add an account from a script:
+  printf '%s\n' "$PASSWORD" | tweethub accounts add brand`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if accountTimezone != "" {
+			_, err := time.LoadLocation(accountTimezone)
+			cobra.CheckErr(err)
+		}
+
+		account := config.Account{
+			Username: strings.TrimPrefix(args[0], "@"),
+			Alias:    accountAlias,
+			Label:    accountLabel,
+			Timezone: accountTimezone,
+			Tags:     accountTags,
+		}
+
+		f, err := config.Load(viper.ConfigFileUsed())
+		cobra.CheckErr(err)
+
+		// Added before asking for the password, so that a taken name fails first.
+		cobra.CheckErr(f.Add(account))
+
+		if dryRun {
+			fmt.Printf("[dry-run] add account @%s to %s\n", account.Username, viper.ConfigFileUsed())
+			return
+		}
+
+		password, err := readPassword(account.Username)
+		cobra.CheckErr(err)
+
+		cobra.CheckErr(f.SetPassword(account.Username, password))
+		cobra.CheckErr(f.Save())
+
+		fmt.Fprintf(os.Stderr, "Added @%s to %s\n", account.Username, viper.ConfigFileUsed())
+	},
+}
+
+// accountsRemoveCmd represents the accounts remove command
+var accountsRemoveCmd = &cobra.Command{
+	Use:   "remove <username|alias>",
+	Short: "Remove an account from the configuration file.",
+	Long: `The remove command removes an account, with its password and settings, from the
+configuration file after asking for confirmation.
+
+Examples:
+- Remove an account:
+  tweethub accounts remove brand
+
+- Remove an account without confirmation:
+  tweethub accounts remove work --yes`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := config.Load(viper.ConfigFileUsed())
+		cobra.CheckErr(err)
+
+		account, err := f.Remove(args[0])
+		cobra.CheckErr(err)
+
+		if dryRun {
+			fmt.Printf("[dry-run] remove account @%s from %s\n", account.Username, viper.ConfigFileUsed())
+			return
+		}
+
+		if !yes && !confirm(fmt.Sprintf("Remove @%s from %s?", account.Username, viper.ConfigFileUsed())) {
+			return
+		}
+
+		cobra.CheckErr(f.Save())
+
+		fmt.Fprintf(os.Stderr, "Removed @%s from %s\n", account.Username, viper.ConfigFileUsed())
+	},
+}
+
+// accountsListCmd represents the accounts list command
+var accountsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the accounts of the configuration file.",
+	Long: `The list command lists the configured accounts with their metadata. Passwords are
+never printed.
+
+Examples:
+- List the accounts:
+  tweethub accounts list
+
+- List the accounts as JSON:
+  tweethub accounts list --format json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if accountsFormat != "text" && accountsFormat != "json" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected text or json", accountsFormat))
+		}
+
+		if accountsFormat == "json" {
+			infos := make([]accountInfo, len(accounts))
+			for i, user := range accounts {
+				infos[i] = newAccountInfo(user)
+			}
+
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			cobra.CheckErr(enc.Encode(infos))
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "USERNAME\tALIAS\tLABEL\tTIMEZONE\tTAGS")
+		for _, user := range accounts {
+			fmt.Fprintf(w, "@%s\t%s\t%s\t%s\t%s\n", user.Username, user.Alias, user.Label, user.Timezone, strings.Join(user.Tags, ","))
+		}
+		w.Flush()
+	},
+}
+
+// accountsShowCmd represents the accounts show command
+var accountsShowCmd = &cobra.Command{
+	Use:   "show [<username|alias>]",
+	Short: "Show the settings of an account.",
+	Long: `The show command prints the settings of an account, the selected one by default.
+The password is not printed, only whether it is set.
+
+Examples:
+- Show the first account:
+  tweethub accounts show
+
+- Show an account as JSON:
+  tweethub accounts show work --format json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if accountsFormat != "text" && accountsFormat != "json" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected text or json", accountsFormat))
+		}
+
+		var user Account
+		if len(args) == 1 {
+			user = findAccount(args[0])
+		} else {
+			user = singleAccount()
+		}
+
+		info := newAccountInfo(user)
+
+		if accountsFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			cobra.CheckErr(enc.Encode(info))
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Username:\t@%s\n", info.Username)
+		if info.Password {
+			fmt.Fprintln(w, "Password:\tset")
+		} else {
+			fmt.Fprintln(w, "Password:\tnot set")
+		}
+		fmt.Fprintf(w, "Alias:\t%s\n", info.Alias)
+		fmt.Fprintf(w, "Label:\t%s\n", info.Label)
+		fmt.Fprintf(w, "Timezone:\t%s\n", info.Timezone)
+		fmt.Fprintf(w, "Tags:\t%s\n", strings.Join(info.Tags, ", "))
+		if r := info.Retention; r != nil {
+			fmt.Fprintf(w, "Retention:\tolder than %d days, keep pinned: %t, keep hashtags: %s, keep above %d likes\n", r.OlderThan, r.KeepPinned, strings.Join(r.KeepHashtags, ", "), r.KeepAboveLikes)
+		}
+		w.Flush()
+	},
+}
+
+// accountInfo is the printable view of an account, without its password.
+type accountInfo struct {
+	Username  string           `json:"username"`
+	Password  bool             `json:"password_set"`
+	Alias     string           `json:"alias,omitempty"`
+	Label     string           `json:"label,omitempty"`
+	Timezone  string           `json:"timezone,omitempty"`
+	Tags      []string         `json:"tags,omitempty"`
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+}
+
+// newAccountInfo returns the printable view of user.
+func newAccountInfo(user Account) accountInfo {
+	return accountInfo{
+		Username:  user.Username,
+		Password:  user.Password != "",
+		Alias:     user.Alias,
+		Label:     user.Label,
+		Timezone:  user.Timezone,
+		Tags:      user.Tags,
+		Retention: user.Retention,
+	}
+}
+
+// readPassword asks for the password of username without echoing it, twice
+// to catch typos. When standard input is not a terminal, its first line is
+// the password.
+func readPassword(username string) (string, error) {
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		password := strings.TrimRight(line, "\r\n")
+		if password == "" {
+			if err == nil {
+				err = fmt.Errorf("empty password")
+			}
+			return "", fmt.Errorf("reading the password from standard input: %v", err)
+		}
+		return password, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Password for @%s: ", username)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	if len(password) == 0 {
+		return "", fmt.Errorf("empty password")
+	}
+
+	fmt.Fprint(os.Stderr, "Repeat the password: ")
+	again, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	if string(again) != string(password) {
+		return "", fmt.Errorf("the passwords do not match")
+	}
+
+	return string(password), nil
+}
+
+func init() {
+	accountsAddCmd.Flags().StringVar(&accountAlias, "alias", "", "Alias to select the account with --account.")
+	accountsAddCmd.Flags().StringVar(&accountLabel, "label", "", "Display label of the account.")
+	accountsAddCmd.Flags().StringVar(&accountTimezone, "timezone", "", "IANA timezone of the account, e.g. Europe/Madrid.")
+	accountsAddCmd.Flags().StringArrayVar(&accountTags, "tag", nil, "Default tag of the account (repeatable).")
+
+	accountsRemoveCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation.")
+
+	accountsListCmd.Flags().StringVar(&accountsFormat, "format", "text", "Output format: text or json.")
+	accountsShowCmd.Flags().StringVar(&accountsFormat, "format", "text", "Output format: text or json.")
+
+	accountsCmd.AddCommand(accountsAddCmd, accountsRemoveCmd, accountsListCmd, accountsShowCmd)
+
+	rootCmd.AddCommand(accountsCmd)
+}
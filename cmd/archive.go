@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alomia/tweethub-cli/internal/archive"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	archiveFormat   string
+	archiveKind     string
+	match           string
+	hasMedia        bool
+	replies         bool
+	retweets        bool
+	excludeReplies  bool
+	excludeRetweets bool
+)
+
+// archiveCmd represents the archive command
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Import and query the data archive of an account.",
+	Long: `The archive command works with the data archive Twitter lets you download from
+"Settings > Your account > Download an archive of your data". Imported archives are
+kept in the "archives" directory next to the configuration file and can be queried
+offline.`,
+	Annotations: optionalAccounts,
+}
+
+// archiveImportCmd represents the archive import command
+var archiveImportCmd = &cobra.Command{
+	Use:   "import <zip>",
+	Short: "Import a downloaded archive zip file.",
+	Long: `The import command reads the tweets, likes, followers and following of the archive
+zip file and stores them for the account the archive belongs to, replacing any
+previous import of that account.
+
+Examples:
+- Import an archive:
+  tweethub archive import twitter-2024-01-31-abc123.zip`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		a, err := archive.Import(args[0])
+		cobra.CheckErr(err)
+
+		if dryRun {
+			fmt.Printf("[dry-run] import archive of @%s to %s: %d tweets, %d likes, %d followers, %d following\n",
+				a.Account, archivePath(a.Account), len(a.Tweets), len(a.Likes), len(a.Followers), len(a.Following))
+			return
+		}
+
+		cobra.CheckErr(os.MkdirAll(archivesDir(), 0o700))
+		cobra.CheckErr(a.Save(archivePath(a.Account)))
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "Imported archive of @%s: %d tweets, %d likes, %d followers, %d following\n",
+			a.Account, len(a.Tweets), len(a.Likes), len(a.Followers), len(a.Following))
+	},
+}
+
+// archiveQueryCmd represents the archive query command
+var archiveQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "List the archived tweets or likes matching some filters.",
+	Long: `The query command prints the URL of every archived tweet matching the filters, one
+per line and newest first, so the output can be fed to other commands. Use
+"--format id" for bare IDs or "--format json" for the full entries. Dates are
+YYYY-MM-DD or RFC 3339 times, both inclusive; "--until" dates include the whole day.
+
+With "--kind likes" the tweets liked by the account are listed instead. The archive
+has no dates nor media for likes, so only "--match" applies to them.
+
+Examples:
+- Tweets of 2019 with media:
+  tweethub archive query --since 2019-01-01 --until 2019-12-31 --has-media
+
+- Delete every reply mentioning "crypto":
+  tweethub archive query --replies --match '(?i)crypto' | xargs -n1 tweethub tweet --undo --url
+
+- Liked tweets as JSON:
+  tweethub archive query --kind likes --format json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := dateRange()
+
+		filter := archive.Filter{
+			Since:           from,
+			Until:           to,
+			HasMedia:        hasMedia,
+			Replies:         replies,
+			Retweets:        retweets,
+			ExcludeReplies:  excludeReplies,
+			ExcludeRetweets: excludeRetweets,
+		}
+
+		if match != "" {
+			re, err := regexp.Compile(match)
+			cobra.CheckErr(err)
+			filter.Match = re
+		}
+
+		if archiveFormat != "url" && archiveFormat != "id" && archiveFormat != "json" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected url, id or json", archiveFormat))
+		}
+
+		if len(accountNames) > 1 {
+			cobra.CheckErr(fmt.Errorf("the archive of one account can be queried at a time, got %d", len(accountNames)))
+		}
+
+		account := ""
+		if len(accountNames) == 1 {
+			account = strings.TrimPrefix(accountNames[0], "@")
+			if user, err := lookupAccount(account); err == nil {
+				account = user.Username
+			}
+		}
+
+		a := loadArchive(account)
+
+		out, err := createOutput(output)
+		cobra.CheckErr(err)
+		defer out.Close()
+
+		enc := json.NewEncoder(out)
+
+		switch archiveKind {
+		case "tweets":
+			for _, tweet := range a.Query(filter) {
+				switch archiveFormat {
+				case "json":
+					cobra.CheckErr(enc.Encode(tweet))
+				case "id":
+					fmt.Fprintln(out, tweet.ID)
+				default:
+					fmt.Fprintln(out, tweet.URL(a.Account))
+				}
+			}
+
+		case "likes":
+			for _, like := range a.Likes {
+				if filter.Match != nil && !filter.Match.MatchString(like.Text) {
+					continue
+				}
+
+				switch archiveFormat {
+				case "json":
+					cobra.CheckErr(enc.Encode(like))
+				case "id":
+					fmt.Fprintln(out, like.ID)
+				default:
+					link := like.URL
+					if link == "" {
+						// Likes exported without expandedUrl only have the ID.
+						link = tweethub.TweetRef{ID: like.ID}.URL()
+					}
+					fmt.Fprintln(out, link)
+				}
+			}
+
+		default:
+			cobra.CheckErr(fmt.Errorf("invalid kind %q, expected tweets or likes", archiveKind))
+		}
+	},
+}
+
+// archivesDir returns the directory imported archives are stored in.
+func archivesDir() string {
+	return filepath.Join(configDir(), "archives")
+}
+
+// archivePath returns the path of the imported archive of account.
+func archivePath(account string) string {
+	return filepath.Join(archivesDir(), strings.ToLower(account)+".json")
+}
+
+// loadArchive loads the imported archive of account. With an empty account,
+// the only imported archive is used.
+func loadArchive(account string) *archive.Archive {
+	if account == "" {
+		paths, _ := filepath.Glob(filepath.Join(archivesDir(), "*.json"))
+
+		switch len(paths) {
+		case 0:
+			cobra.CheckErr(fmt.Errorf("no archive imported, run \"tweethub archive import <zip>\" first"))
+		case 1:
+			account = strings.TrimSuffix(filepath.Base(paths[0]), ".json")
+		default:
+			cobra.CheckErr(fmt.Errorf("several archives imported, choose one with --account"))
+		}
+	}
+
+	a, err := archive.Load(archivePath(account))
+	if os.IsNotExist(err) {
+		err = fmt.Errorf("no archive imported for @%s", account)
+	}
+	cobra.CheckErr(err)
+
+	return a
+}
+
+func init() {
+	archiveQueryCmd.Flags().StringVar(&archiveKind, "kind", "tweets", "What to query: tweets or likes.")
+	archiveQueryCmd.Flags().StringVar(&since, "since", "", "Only tweets created on or after this date.")
+	archiveQueryCmd.Flags().StringVar(&until, "until", "", "Only tweets created on or before this date.")
+	archiveQueryCmd.Flags().StringVar(&match, "match", "", "Only entries whose text matches this regular expression.")
+	archiveQueryCmd.Flags().BoolVar(&hasMedia, "has-media", false, "Only tweets with photos, GIFs or videos.")
+	archiveQueryCmd.Flags().BoolVar(&replies, "replies", false, "Only replies.")
+	archiveQueryCmd.Flags().BoolVar(&retweets, "retweets", false, "Only retweets.")
+	archiveQueryCmd.Flags().BoolVar(&excludeReplies, "exclude-replies", false, "Leave out replies.")
+	archiveQueryCmd.Flags().BoolVar(&excludeRetweets, "exclude-retweets", false, "Leave out retweets.")
+	archiveQueryCmd.Flags().StringVar(&archiveFormat, "format", "url", "Output format: url, id or json.")
+	archiveQueryCmd.Flags().StringVarP(&output, "output", "o", "-", `Output file, "-" for standard output.`)
+
+	archiveCmd.AddCommand(archiveImportCmd)
+	archiveCmd.AddCommand(archiveQueryCmd)
+
+	rootCmd.AddCommand(archiveCmd)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alomia/tweethub-cli/internal/audit"
+	"github.com/alomia/tweethub-cli/internal/store"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var auditFile string
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Check the audit log of the actions run on Twitter.",
+	Long: `Every action run on Twitter is appended to the audit log, "audit.jsonl" next to the
+configuration file unless "audit_log" is set in it. Each entry carries the hash of
+the previous entry, so the log can be checked for entries edited or removed after
+the fact. Set "audit_hmac_key" in the configuration file to sign the hashes with a
+secret key, so that they cannot be recomputed without it.`,
+	Annotations: optionalAccounts,
+}
+
+// auditVerifyCmd represents the audit verify command
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the hash chain of the audit log.",
+	Long: `The verify command walks the audit log and checks the hash of every entry and its
+link to the previous one. It reports the first broken link and exits with an error.
+
+Examples:
+- Check the audit log:
+  tweethub audit verify
+
+- Check a copy of the log:
+  tweethub audit verify --file backup/audit.jsonl`,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := auditFile
+		if path == "" {
+			path = auditLogPath()
+		}
+
+		n, err := audit.Open(path, auditKey()).Verify()
+		if err != nil {
+			fmt.Printf("%s: %d entries verified\n", path, n)
+		}
+		cobra.CheckErr(err)
+
+		fmt.Printf("%s: %d entries verified, the chain is intact\n", path, n)
+	},
+}
+
+// auditLogPath returns the path of the audit log, set with the "audit_log"
+// configuration key and relative to the configuration file.
+func auditLogPath() string {
+	path := viper.GetString("audit_log")
+	if path == "" {
+		path = "audit.jsonl"
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(configDir(), path)
+	}
+
+	return path
+}
+
+// auditKey returns the HMAC key of the audit log, set with the
+// "audit_hmac_key" configuration key.
+func auditKey() []byte {
+	return []byte(viper.GetString("audit_hmac_key"))
+}
+
+// recordTweetHubAction appends the record of an action to the audit log and
+// saves its outcome in the history store.
+func recordTweetHubAction(r tweethub.ActionRecord) {
+	entry := audit.Entry{
+		Account:     r.Account,
+		Action:      r.Action,
+		Target:      r.Target,
+		MessageHash: audit.HashMessage(r.Message),
+		Outcome:     r.Outcome,
+		URL:         r.URL,
+	}
+	if r.Err != nil {
+		entry.Error = r.Err.Error()
+	}
+
+	if err := audit.Open(auditLogPath(), auditKey()).Write(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write to the audit log: %v\n", err)
+	}
+
+	record(func(s *store.Store) error {
+		return s.RecordAction(store.Action{
+			Account: r.Account,
+			Action:  r.Action,
+			Target:  r.Target,
+			Result:  r.Outcome,
+			Error:   entry.Error,
+			URL:     r.URL,
+			Undoes:  undoing,
+		})
+	})
+}
+
+func init() {
+	auditVerifyCmd.Flags().StringVar(&auditFile, "file", "", "Audit log to check (default is the configured audit log).")
+
+	auditCmd.AddCommand(auditVerifyCmd)
+
+	rootCmd.AddCommand(auditCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// blockCmd represents the block command
+var blockCmd = &cobra.Command{
+	Use:   "block",
+	Short: "Block or unblock a Twitter user.",
+	Long: `The block command allows you to block or unblock a specified user.
+If the "--undo" flag is provided, it will undo the action, i.e., unblock the user.
+Users that are already in the requested state are skipped.
+
+Examples:
+- Block a user:
+  tweethub block --username <target-username>
+
+- Unblock a user:
+  tweethub block --username <target-username> --undo`,
+	Run: func(cmd *cobra.Command, args []string) {
+		switch {
+		case undo:
+			if len(selected) > 1 {
+				for _, user := range selected {
+					tweetHub.SetUsername(user.Username)
+					tweetHub.SetPassword(user.Password)
+
+					cancel := tweetHub.UnBlock(username)
+					cancel()
+				}
+				return
+			}
+			cancel := tweetHub.UnBlock(username)
+			defer cancel()
+		default:
+			if len(selected) > 1 {
+				for _, user := range selected {
+					tweetHub.SetUsername(user.Username)
+					tweetHub.SetPassword(user.Password)
+
+					cancel := tweetHub.Block(username)
+					cancel()
+				}
+				return
+			}
+			cancel := tweetHub.Block(username)
+			defer cancel()
+		}
+	},
+}
+
+func init() {
+	blockCmd.Flags().StringVarP(&username, "username", "u", "", "Specify the target Twitter username.")
+	blockCmd.Flags().BoolVar(&undo, "undo", false, "Undo the block action (unblock).")
+	blockCmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "Use all accounts")
+
+	blockCmd.MarkFlagRequired("username")
+
+	rootCmd.AddCommand(blockCmd)
+}
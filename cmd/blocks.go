@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	handlesFormat string
+	report        string
+)
+
+// handlePattern matches a valid Twitter username, without the "@".
+var handlePattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,15}$`)
+
+// handleResult is the outcome of applying a list entry for an account, as
+// written to the report.
+type handleResult struct {
+	Account  string `json:"account"`
+	Username string `json:"username"`
+	Result   string `json:"result"`
+	Error    string `json:"error,omitempty"`
+}
+
+// blocksCmd represents the blocks command
+var blocksCmd = &cobra.Command{
+	Use:   "blocks",
+	Short: "Export or import the list of blocked users.",
+	Long: `The blocks command manages the list of users blocked by the account,
+so a shared blocklist can be exported from one account and applied to others.
+
+Lists are CSV files with one username per line (a header line is allowed) or
+JSON arrays of usernames, chosen by the file extension.
+
+Examples:
+- Export the blocked users:
+  tweethub blocks export --output blocklist.csv
+
+- Apply a shared blocklist to every account:
+  tweethub blocks import blocklist.csv --all-accounts --report report.json`,
+}
+
+// blocksExportCmd represents the blocks export command
+var blocksExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the users blocked by the account.",
+	Run: func(cmd *cobra.Command, args []string) {
+		exportHandles("blocked users", (*tweethub.Session).Blocked)
+	},
+}
+
+// blocksImportCmd represents the blocks import command
+var blocksImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Block every user of a list.",
+	Long: `The import command blocks every user of the list, skipping users that are
+already blocked. With "--undo" it unblocks them instead.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		apply, verb := (*tweethub.Session).Block, "blocked"
+		if undo {
+			apply, verb = (*tweethub.Session).UnBlock, "unblocked"
+		}
+
+		importHandles(args[0], verb, apply)
+	},
+}
+
+// exportHandles writes the usernames of the kind users returned by list for
+// the account to --output, as CSV or JSON depending on --format.
+func exportHandles(kind string, list func(*tweethub.Session) ([]string, error)) {
+	if handlesFormat != "csv" && handlesFormat != "json" {
+		cobra.CheckErr(fmt.Errorf("invalid format %q, expected csv or json", handlesFormat))
+	}
+
+	useAccount(singleAccount())
+
+	if plannedRead(kind) {
+		return
+	}
+
+	s, cancel, err := tweetHub.NewSession()
+	defer cancel()
+	cobra.CheckErr(err)
+
+	usernames, err := list(s)
+	cobra.CheckErr(err)
+
+	out, err := createOutput(output)
+	cobra.CheckErr(err)
+	defer out.Close()
+
+	cobra.CheckErr(writeHandles(out, handlesFormat, usernames))
+}
+
+// importHandles applies fn to every username of the list at path, for each
+// selected account over a single session, printing the progress and a summary.
+func importHandles(path, verb string, fn func(*tweethub.Session, string) (bool, error)) {
+	usernames, err := readHandles(path)
+	cobra.CheckErr(err)
+
+	var results []handleResult
+
+	for _, user := range selected {
+		tweetHub.SetUsername(user.Username)
+		tweetHub.SetPassword(user.Password)
+
+		s, cancel, err := tweetHub.NewSession()
+		if err != nil {
+			cancel()
+
+			fmt.Fprintf(os.Stderr, "@%s: %v\n", user.Username, err)
+			for _, username := range usernames {
+				results = append(results, handleResult{Account: user.Username, Username: username, Result: "failed", Error: err.Error()})
+			}
+			fmt.Printf("@%s: 0 %s, 0 skipped, %d failed\n", user.Username, verb, len(usernames))
+			continue
+		}
+
+		counts := make(map[string]int)
+
+		for i, username := range usernames {
+			result := handleResult{Account: user.Username, Username: username, Result: verb}
+
+			changed, err := fn(s, username)
+			switch {
+			case err != nil:
+				result.Result, result.Error = "failed", err.Error()
+			case !changed:
+				result.Result = "skipped"
+			}
+
+			counts[result.Result]++
+			results = append(results, result)
+
+			fmt.Printf("[%d/%d] @%s: %s", i+1, len(usernames), username, result.Result)
+			if result.Error != "" {
+				fmt.Printf(" (%s)", result.Error)
+			}
+			fmt.Println()
+		}
+
+		cancel()
+
+		fmt.Printf("@%s: %d %s, %d skipped, %d failed\n", user.Username, counts[verb], verb, counts["skipped"], counts["failed"])
+	}
+
+	if report != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		cobra.CheckErr(err)
+		cobra.CheckErr(os.WriteFile(report, append(data, '\n'), 0o644))
+	}
+}
+
+// readHandles reads a list of usernames from a JSON or CSV file. Leading "@"
+// signs, blank lines, a header line and duplicates are dropped, and entries
+// that are not valid usernames are an error.
+func readHandles(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []string
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", path, err)
+		}
+	} else {
+		r := csv.NewReader(strings.NewReader(string(data)))
+		r.FieldsPerRecord = -1
+
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", path, err)
+		}
+
+		for i, record := range records {
+			if i == 0 && (strings.EqualFold(record[0], "username") || strings.EqualFold(record[0], "handle")) {
+				continue
+			}
+			entries = append(entries, record[0])
+		}
+	}
+
+	seen := make(map[string]bool)
+	var usernames []string
+
+	for _, entry := range entries {
+		username := strings.TrimPrefix(strings.TrimSpace(entry), "@")
+		if username == "" || seen[strings.ToLower(username)] {
+			continue
+		}
+
+		if !handlePattern.MatchString(username) {
+			return nil, fmt.Errorf("%s: invalid username %q", path, entry)
+		}
+
+		seen[strings.ToLower(username)] = true
+		usernames = append(usernames, username)
+	}
+
+	return usernames, nil
+}
+
+// writeHandles writes usernames to w as a JSON array or a CSV file with a header.
+func writeHandles(w io.Writer, format string, usernames []string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(usernames)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"username"})
+	for _, username := range usernames {
+		cw.Write([]string{username})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func init() {
+	blocksExportCmd.Flags().StringVar(&handlesFormat, "format", "csv", "Output format: csv or json.")
+	blocksExportCmd.Flags().StringVarP(&output, "output", "o", "-", `Output file, "-" for standard output.`)
+
+	blocksImportCmd.Flags().BoolVar(&undo, "undo", false, "Unblock the users of the list instead.")
+	blocksImportCmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "Use all accounts")
+	blocksImportCmd.Flags().StringVar(&report, "report", "", "Write the per-entry results as JSON to this file.")
+
+	blocksCmd.AddCommand(blocksExportCmd)
+	blocksCmd.AddCommand(blocksImportCmd)
+
+	rootCmd.AddCommand(blocksCmd)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadHandles(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "csv with header",
+			file:    "blocked.csv",
+			content: "username\n@alice\nbob\n\n",
+			want:    []string{"alice", "bob"},
+		},
+		{
+			name:    "csv with handle header and more columns",
+			file:    "blocked.csv",
+			content: "handle,reason\nalice,spam\n bob ,\"rude, twice\"\n",
+			want:    []string{"alice", "bob"},
+		},
+		{
+			name:    "plain text",
+			file:    "blocked.txt",
+			content: "alice\n@Bob\n",
+			want:    []string{"alice", "Bob"},
+		},
+		{
+			name:    "duplicates",
+			file:    "blocked.csv",
+			content: "alice\n@ALICE\nbob\nalice\n",
+			want:    []string{"alice", "bob"},
+		},
+		{
+			name:    "json",
+			file:    "blocked.JSON",
+			content: `["@alice", "bob", ""]`,
+			want:    []string{"alice", "bob"},
+		},
+		{
+			name:    "invalid json",
+			file:    "blocked.json",
+			content: `{"username": "alice"}`,
+			wantErr: "parsing",
+		},
+		{
+			name:    "url",
+			file:    "blocked.csv",
+			content: "https://twitter.com/alice\n",
+			wantErr: `invalid username "https://twitter.com/alice"`,
+		},
+		{
+			name:    "too long",
+			file:    "blocked.csv",
+			content: "a_username_too_long\n",
+			wantErr: "invalid username",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readHandles(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readHandles() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readHandles() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readHandles() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHandlesRoundTrip(t *testing.T) {
+	usernames := []string{"alice", "bob"}
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeHandles(&buf, format, usernames); err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join(t.TempDir(), "handles."+format)
+			if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readHandles(path)
+			if err != nil {
+				t.Fatalf("readHandles() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, usernames) {
+				t.Errorf("readHandles() = %q, want %q", got, usernames)
+			}
+		})
+	}
+}
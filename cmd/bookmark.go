@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	format string
+	output string
+	limit  int
+)
+
+// bookmarkCmd represents the bookmark command
+var bookmarkCmd = &cobra.Command{
+	Use:   "bookmark",
+	Short: "Bookmark or unbookmark a tweet.",
+	Long: `The bookmark command allows you to add a tweet to your bookmarks or remove it from them.
+You can specify the tweet's URL or status ID using the "--url" flag. If the "--undo" flag is provided,
+it will undo the action, i.e., remove the bookmark.
+
+Examples:
+- Bookmark a tweet:
+  tweethub bookmark --url <tweet-url>
+
+- Remove a bookmark:
+  tweethub bookmark --url <tweet-url> --undo
+
+- Export the bookmarks as Markdown:
+  tweethub bookmark list --format markdown --output bookmarks.md`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ref, err := tweethub.ParseTweetRef(url)
+		cobra.CheckErr(err)
+		tweetURL := ref.URL()
+
+		switch {
+		case undo:
+			if len(selected) > 1 {
+				for _, user := range selected {
+					tweetHub.SetUsername(user.Username)
+					tweetHub.SetPassword(user.Password)
+
+					cancel := tweetHub.UnBookmark(tweetURL)
+					cancel()
+				}
+				return
+			}
+			cancel := tweetHub.UnBookmark(tweetURL)
+			defer cancel()
+		default:
+			if len(selected) > 1 {
+				for _, user := range selected {
+					tweetHub.SetUsername(user.Username)
+					tweetHub.SetPassword(user.Password)
+
+					cancel := tweetHub.Bookmark(tweetURL)
+					cancel()
+				}
+				return
+			}
+			cancel := tweetHub.Bookmark(tweetURL)
+			defer cancel()
+		}
+	},
+}
+
+// bookmarkListCmd represents the bookmark list command
+var bookmarkListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Export the bookmarks of the account.",
+	Long: `The list command exports the bookmarks of the account as JSON or Markdown.
+
+Examples:
+- Print the bookmarks as JSON:
+  tweethub bookmark list
+
+- Save the latest 50 bookmarks as Markdown:
+  tweethub bookmark list --limit 50 --format markdown --output bookmarks.md`,
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		if format != "json" && format != "markdown" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected json or markdown", format))
+		}
+
+		if plannedRead("bookmarks") {
+			return
+		}
+
+		posts, cancel, err := tweetHub.Bookmarks(limit)
+		defer cancel()
+		cobra.CheckErr(err)
+
+		out, err := createOutput(output)
+		cobra.CheckErr(err)
+		defer out.Close()
+
+		if format == "markdown" {
+			cobra.CheckErr(writeMarkdown(out, "Bookmarks of @"+tweetHub.Username(), posts))
+			return
+		}
+
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		cobra.CheckErr(enc.Encode(posts))
+	},
+}
+
+// writeMarkdown writes posts as a Markdown list under the given title.
+func writeMarkdown(w io.Writer, title string, posts []tweethub.Post) error {
+	if _, err := fmt.Fprintf(w, "# %s\n\n", title); err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		_, err := fmt.Fprintf(w, "- [@%s, %s](%s): %s\n", post.Author, post.CreatedAt.Format("2006-01-02"), post.URL, oneLine(post.Text))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	bookmarkCmd.Flags().StringVar(&url, "url", "", "Specify the URL or status ID of the tweet.")
+	bookmarkCmd.Flags().BoolVar(&undo, "undo", false, "Undo the bookmark action (remove the bookmark).")
+	bookmarkCmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "Use all accounts")
+
+	bookmarkCmd.MarkFlagRequired("url")
+
+	bookmarkListCmd.Flags().StringVar(&format, "format", "json", "Output format: json or markdown.")
+	bookmarkListCmd.Flags().StringVarP(&output, "output", "o", "-", `Output file, "-" for standard output.`)
+	bookmarkListCmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of bookmarks to export (0 for all).")
+
+	bookmarkCmd.AddCommand(bookmarkListCmd)
+
+	rootCmd.AddCommand(bookmarkCmd)
+}
@@ -0,0 +1,468 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/archive"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanupInput  string
+	checkpoint    string
+	olderThan     int
+	keepAbove     int
+	excludePinned bool
+	fromArchive   bool
+	yes           bool
+
+	// stdinRead is set once standard input was read as data, so that
+	// confirm asks on the terminal instead.
+	stdinRead bool
+)
+
+// cleanupResult is the outcome of cleaning up one tweet, as written to the report.
+type cleanupResult struct {
+	ID     string `json:"id"`
+	URL    string `json:"url"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// cleanupJob describes what a cleanup subcommand removes and how.
+type cleanupJob struct {
+	// kind names the cleaned up items, e.g. "tweets".
+	kind string
+	// verb is the past tense of the action, e.g. "deleted".
+	verb string
+	// list enumerates the candidate tweets of the account.
+	list func(s *tweethub.Session, fn func(tweethub.Post) bool) error
+	// archived returns the candidate tweets of the imported archive of the account.
+	archived func(a *archive.Archive) []tweethub.Post
+	// archivedUndated reports that the archived tweets have no dates.
+	archivedUndated bool
+	// own reports that the tweets must be tweets of the account.
+	own bool
+	// apply removes the tweet at the given URL.
+	apply func(s *tweethub.Session, tweetURL string) error
+}
+
+// cleanupCmd represents the cleanup command
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Remove tweets, likes or reposts of an account in bulk.",
+	Long: `The cleanup command removes many tweets, likes or reposts of an account in a single
+logged-in session. The tweets are either read from a file of URLs or IDs, one per
+line, or found on the account, or in its imported archive with "--from-archive",
+and selected with filters. The filters do not apply to tweets read from a file.
+
+Before removing anything the selected tweets are counted and a confirmation is
+asked; "--dry-run" only lists them. Progress is saved to a checkpoint file after
+each tweet, so an interrupted run picks up where it stopped when run again. The
+checkpoint is removed once a run completes without failures.`,
+}
+
+// cleanupTweetsCmd represents the cleanup tweets command
+var cleanupTweetsCmd = &cobra.Command{
+	Use:   "tweets",
+	Short: "Delete tweets of the account in bulk.",
+	Long: `The tweets command deletes tweets of the account. Without "--input" it scrolls the
+profile timeline and deletes the tweets matching every filter given. Input URLs of
+tweets of other accounts are rejected before anything is deleted; bare IDs of them
+fail, since only the account's own tweets can be deleted. With "--input -" the
+confirmation is asked on the terminal.
+
+Examples:
+- Preview the tweets older than a year that would be deleted:
+  tweethub cleanup tweets --older-than 365 --exclude-pinned --dry-run
+
+- Delete old tweets, keeping the popular ones, without asking:
+  tweethub cleanup tweets --older-than 365 --keep-above 50 --yes --report report.json
+
+- Delete the archived replies mentioning "crypto":
+  tweethub archive query --replies --match '(?i)crypto' | tweethub cleanup tweets --input -`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runCleanup(cleanupJob{
+			kind: "tweets",
+			verb: "deleted",
+			list: func(s *tweethub.Session, fn func(tweethub.Post) bool) error {
+				return s.Timeline(s.Username(), fn)
+			},
+			archived: func(a *archive.Archive) []tweethub.Post {
+				return archivedPosts(a, a.Query(archive.Filter{ExcludeRetweets: true}))
+			},
+			own:   true,
+			apply: (*tweethub.Session).UnTweet,
+		})
+	},
+}
+
+// cleanupLikesCmd represents the cleanup likes command
+var cleanupLikesCmd = &cobra.Command{
+	Use:   "likes",
+	Short: "Unlike tweets in bulk.",
+	Long: `The likes command removes likes of the account. Without "--input" it scrolls the
+likes tab of the account and unlikes the tweets matching every filter given. Twitter
+does not tell when a tweet was liked, so "--older-than" is the age of the liked
+tweet, not of the like. The archive has no dates for likes at all, so "--older-than"
+cannot be used with "--from-archive".
+
+Examples:
+- Unlike every tweet liked by the account:
+  tweethub cleanup likes
+
+- Unlike the tweets older than 90 days mentioning "giveaway":
+  tweethub cleanup likes --older-than 90 --match '(?i)giveaway' --report report.json
+
+- Unlike every tweet of the imported archive:
+  tweethub cleanup likes --from-archive --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runCleanup(cleanupJob{
+			kind: "likes",
+			verb: "unliked",
+			list: func(s *tweethub.Session, fn func(tweethub.Post) bool) error {
+				return s.Likes(s.Username(), fn)
+			},
+			archived: func(a *archive.Archive) []tweethub.Post {
+				var posts []tweethub.Post
+				for _, like := range a.Likes {
+					ref := tweethub.TweetRef{ID: like.ID}
+					posts = append(posts, tweethub.Post{ID: like.ID, URL: ref.URL(), Text: like.Text})
+				}
+				return posts
+			},
+			archivedUndated: true,
+			apply:           (*tweethub.Session).UnLike,
+		})
+	},
+}
+
+// cleanupRepostsCmd represents the cleanup reposts command
+var cleanupRepostsCmd = &cobra.Command{
+	Use:   "reposts",
+	Short: "Undo reposts in bulk.",
+	Long: `The reposts command removes reposts of the account. Without "--input" it scrolls the
+profile timeline and undoes the reposts matching every filter given; dates and
+counts are those of the reposted tweets.
+
+Examples:
+- Preview the reposts of tweets older than a month:
+  tweethub cleanup reposts --older-than 30 --dry-run
+
+- Undo the retweets of the imported archive:
+  tweethub cleanup reposts --from-archive`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runCleanup(cleanupJob{
+			kind: "reposts",
+			verb: "unreposted",
+			list: func(s *tweethub.Session, fn func(tweethub.Post) bool) error {
+				return s.Reposts(s.Username(), fn)
+			},
+			archived: func(a *archive.Archive) []tweethub.Post {
+				return archivedPosts(a, a.Query(archive.Filter{Retweets: true}))
+			},
+			apply: (*tweethub.Session).UnRepost,
+		})
+	},
+}
+
+// runCleanup selects the tweets of job, asks for confirmation and applies job
+// to each of them, keeping the checkpoint and printing progress and a summary.
+func runCleanup(job cleanupJob) {
+	var re *regexp.Regexp
+	if match != "" {
+		var err error
+		re, err = regexp.Compile(match)
+		cobra.CheckErr(err)
+	}
+
+	if fromArchive && olderThan > 0 && job.archivedUndated {
+		cobra.CheckErr(fmt.Errorf("--older-than cannot be used with --from-archive: the archive has no dates for %s", job.kind))
+	}
+
+	useAccount(singleAccount())
+
+	var s *tweethub.Session
+	cancel := func() {}
+	defer func() { cancel() }()
+
+	openSession := func() {
+		var err error
+		s, cancel, err = tweetHub.NewSession()
+		cobra.CheckErr(err)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -olderThan)
+
+	var posts []tweethub.Post
+	undated := 0
+
+	add := func(post tweethub.Post) bool {
+		switch {
+		case olderThan > 0 && post.CreatedAt.IsZero():
+			// The age of the tweet is unknown, so it cannot be older.
+			undated++
+		case olderThan > 0 && !post.CreatedAt.Before(cutoff):
+		case re != nil && !re.MatchString(post.Text):
+		case excludePinned && post.Pinned:
+		case keepAbove > 0 && post.Likes+post.Reposts+post.Replies > keepAbove:
+		default:
+			posts = append(posts, post)
+		}
+
+		return true
+	}
+
+	switch {
+	case cleanupInput != "":
+		var err error
+		posts, err = readTweetRefs(cleanupInput)
+		cobra.CheckErr(err)
+
+	case fromArchive:
+		for _, post := range job.archived(loadArchive(tweetHub.Username())) {
+			add(post)
+		}
+
+	default:
+		openSession()
+		cobra.CheckErr(job.list(s, add))
+	}
+
+	if undated > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %d %s without a date, --older-than cannot apply to them\n", undated, job.kind)
+	}
+
+	account := tweetHub.Username()
+
+	if job.own {
+		for _, post := range posts {
+			if post.Author != "" && !strings.EqualFold(post.Author, account) {
+				cobra.CheckErr(fmt.Errorf("%s is a tweet of @%s, not of @%s", post.URL, post.Author, account))
+			}
+		}
+	}
+
+	path := checkpoint
+	if path == "" {
+		path = filepath.Join(configDir(), "checkpoints", fmt.Sprintf("cleanup-%s-%s.txt", strings.ToLower(account), job.kind))
+	}
+
+	done, err := readCheckpoint(path)
+	cobra.CheckErr(err)
+
+	var pending []tweethub.Post
+	for _, post := range posts {
+		if !done[post.ID] {
+			pending = append(pending, post)
+		}
+	}
+
+	if len(done) > 0 {
+		fmt.Fprintf(os.Stderr, "Resuming from %s: %d %s already %s\n", path, len(posts)-len(pending), job.kind, job.verb)
+	}
+
+	if len(pending) == 0 {
+		fmt.Fprintf(os.Stderr, "No %s to clean up for @%s\n", job.kind, account)
+		return
+	}
+
+	if dryRun {
+		for _, post := range pending {
+			if post.Text == "" {
+				fmt.Println(post.URL)
+			} else {
+				fmt.Printf("%s\t%s\n", post.URL, oneLine(post.Text))
+			}
+		}
+		fmt.Fprintf(os.Stderr, "%d %s of @%s would be %s\n", len(pending), job.kind, account, job.verb)
+		return
+	}
+
+	if !yes && !confirm(fmt.Sprintf("%d %s of @%s will be %s. Continue?", len(pending), job.kind, account, job.verb)) {
+		fmt.Fprintln(os.Stderr, "Aborted")
+		return
+	}
+
+	if s == nil {
+		openSession()
+	}
+
+	cobra.CheckErr(os.MkdirAll(filepath.Dir(path), 0o700))
+	checkpointFile, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	cobra.CheckErr(err)
+	defer checkpointFile.Close()
+
+	var results []cleanupResult
+	counts := make(map[string]int)
+
+	for i, post := range pending {
+		result := cleanupResult{ID: post.ID, URL: post.URL, Result: job.verb}
+
+		if err := job.apply(s, post.URL); err != nil {
+			result.Result, result.Error = "failed", err.Error()
+		} else {
+			fmt.Fprintln(checkpointFile, post.ID)
+		}
+
+		counts[result.Result]++
+		results = append(results, result)
+
+		fmt.Printf("[%d/%d] %s: %s", i+1, len(pending), post.URL, result.Result)
+		if result.Error != "" {
+			fmt.Printf(" (%s)", result.Error)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("@%s: %d %s, %d failed\n", account, counts[job.verb], job.verb, counts["failed"])
+
+	if counts["failed"] == 0 {
+		checkpointFile.Close()
+		os.Remove(path)
+	}
+
+	if report != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		cobra.CheckErr(err)
+		cobra.CheckErr(os.WriteFile(report, append(data, '\n'), 0o644))
+	}
+}
+
+// archivedPosts converts tweets of the archive a to posts.
+func archivedPosts(a *archive.Archive, tweets []archive.Tweet) []tweethub.Post {
+	var posts []tweethub.Post
+
+	for _, tweet := range tweets {
+		posts = append(posts, tweethub.Post{
+			ID:        tweet.ID,
+			URL:       tweet.URL(a.Account),
+			Author:    a.Account,
+			Text:      tweet.Text,
+			CreatedAt: tweet.CreatedAt,
+			Media:     tweet.Media,
+			Likes:     tweet.Likes,
+			Reposts:   tweet.Retweets,
+		})
+	}
+
+	return posts
+}
+
+// readTweetRefs reads tweet URLs or IDs, one per line, from path or from
+// standard input when path is "-". Blank lines, comments and duplicates are
+// skipped.
+func readTweetRefs(path string) ([]tweethub.Post, error) {
+	var r io.Reader = os.Stdin
+
+	if path == "-" {
+		stdinRead = true
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	seen := make(map[string]bool)
+	var posts []tweethub.Post
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ref, err := tweethub.ParseTweetRef(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[ref.ID] {
+			continue
+		}
+
+		seen[ref.ID] = true
+		posts = append(posts, tweethub.Post{ID: ref.ID, URL: ref.URL(), Author: ref.Username})
+	}
+
+	return posts, scanner.Err()
+}
+
+// readCheckpoint returns the IDs recorded in the checkpoint file at path. A
+// missing file means nothing was done yet.
+func readCheckpoint(path string) (map[string]bool, error) {
+	done := make(map[string]bool)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range strings.Fields(string(data)) {
+		done[id] = true
+	}
+
+	return done, nil
+}
+
+// confirm asks question on standard error and reports whether the answer read
+// from standard input is yes. When standard input was already read as data,
+// the answer is read from the terminal; without one the answer is no.
+func confirm(question string) bool {
+	var in io.Reader = os.Stdin
+
+	if stdinRead {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot ask for confirmation: standard input is the input and there is no terminal, use --yes")
+			return false
+		}
+		defer tty.Close()
+		in = tty
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+
+	return false
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{cleanupTweetsCmd, cleanupLikesCmd, cleanupRepostsCmd} {
+		cmd.Flags().StringVar(&cleanupInput, "input", "", `File of tweet URLs or IDs to clean up, one per line, "-" for standard input.`)
+		cmd.Flags().BoolVar(&fromArchive, "from-archive", false, "Select the tweets from the imported archive of the account instead.")
+		cmd.Flags().IntVar(&olderThan, "older-than", 0, "Only tweets older than this number of days.")
+		cmd.Flags().StringVar(&match, "match", "", "Only tweets whose text matches this regular expression.")
+		cmd.Flags().BoolVar(&excludePinned, "exclude-pinned", false, "Keep the pinned tweet.")
+		cmd.Flags().IntVar(&keepAbove, "keep-above", 0, "Keep tweets with more than this number of likes, reposts and replies combined.")
+		cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation.")
+		cmd.Flags().StringVar(&checkpoint, "checkpoint", "", "Checkpoint file (default is in the checkpoints directory next to the config file).")
+		cmd.Flags().StringVar(&report, "report", "", "Write the per-tweet results as JSON to this file.")
+
+		cleanupCmd.AddCommand(cmd)
+	}
+
+	rootCmd.AddCommand(cleanupCmd)
+}
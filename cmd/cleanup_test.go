@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+)
+
+// setupCleanup sets the flags of a cleanup run for the account alice, with
+// a checkpoint file in a temporary directory, and restores them afterwards.
+// The sessions of the TweetHub instance never start a browser: they are in
+// dry-run mode, while the cleanup itself is not.
+func setupCleanup(t *testing.T) {
+	t.Helper()
+
+	savedHub, savedSelected := tweetHub, selected
+	t.Cleanup(func() {
+		tweetHub, selected = savedHub, savedSelected
+		match, olderThan, keepAbove, excludePinned = "", 0, 0, false
+		cleanupInput, checkpoint, report = "", "", ""
+		fromArchive, yes, dryRun = false, false, false
+	})
+
+	tweetHub = tweethub.New()
+	tweetHub.SetDryRun(true)
+	selected = []Account{{Username: "alice"}}
+
+	checkpoint = filepath.Join(t.TempDir(), "checkpoint.txt")
+	yes = true
+}
+
+// cleanupRecorder is a cleanup job listing posts and recording the URLs it
+// is applied to. Applying it to the URLs in fail fails.
+type cleanupRecorder struct {
+	posts   []tweethub.Post
+	fail    map[string]bool
+	applied []string
+}
+
+func (r *cleanupRecorder) job() cleanupJob {
+	return cleanupJob{
+		kind: "tweets",
+		verb: "deleted",
+		list: func(s *tweethub.Session, fn func(tweethub.Post) bool) error {
+			for _, post := range r.posts {
+				if !fn(post) {
+					break
+				}
+			}
+			return nil
+		},
+		own: true,
+		apply: func(s *tweethub.Session, tweetURL string) error {
+			r.applied = append(r.applied, tweetURL)
+			if r.fail[tweetURL] {
+				return errors.New("not found")
+			}
+			return nil
+		},
+	}
+}
+
+// post returns a post of alice with the given ID created days ago.
+func post(id string, days int) tweethub.Post {
+	return tweethub.Post{
+		ID:        id,
+		URL:       "https://twitter.com/alice/status/" + id,
+		Author:    "alice",
+		Text:      "tweet " + id,
+		CreatedAt: time.Now().AddDate(0, 0, -days),
+	}
+}
+
+func TestRunCleanupFilters(t *testing.T) {
+	pinned := post("3", 400)
+	pinned.Pinned = true
+
+	popular := post("4", 400)
+	popular.Likes, popular.Reposts, popular.Replies = 40, 5, 6
+
+	undated := post("5", 0)
+	undated.CreatedAt = time.Time{}
+
+	matching := post("6", 400)
+	matching.Text = "Win a CRYPTO prize"
+
+	posts := []tweethub.Post{post("1", 400), post("2", 10), pinned, popular, undated, matching}
+
+	tests := []struct {
+		name  string
+		setup func()
+		want  []string
+	}{
+		{
+			name: "no filter",
+			want: []string{"1", "2", "3", "4", "5", "6"},
+		},
+		{
+			name:  "older than",
+			setup: func() { olderThan = 365 },
+			want:  []string{"1", "3", "4", "6"},
+		},
+		{
+			name:  "exclude pinned",
+			setup: func() { excludePinned = true },
+			want:  []string{"1", "2", "4", "5", "6"},
+		},
+		{
+			name:  "keep above",
+			setup: func() { keepAbove = 50 },
+			want:  []string{"1", "2", "3", "5", "6"},
+		},
+		{
+			name:  "match",
+			setup: func() { match = "(?i)crypto" },
+			want:  []string{"6"},
+		},
+		{
+			name: "every filter",
+			setup: func() {
+				olderThan, excludePinned, keepAbove = 365, true, 50
+			},
+			want: []string{"1", "6"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupCleanup(t)
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			r := &cleanupRecorder{posts: posts}
+			runCleanup(r.job())
+
+			var want []string
+			for _, id := range tt.want {
+				want = append(want, "https://twitter.com/alice/status/"+id)
+			}
+
+			if !reflect.DeepEqual(r.applied, want) {
+				t.Errorf("applied to %q, want %q", r.applied, want)
+			}
+
+			if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+				t.Errorf("the checkpoint of a run without failures was kept: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunCleanupDryRun(t *testing.T) {
+	setupCleanup(t)
+	dryRun = true
+
+	r := &cleanupRecorder{posts: []tweethub.Post{post("1", 400)}}
+	runCleanup(r.job())
+
+	if len(r.applied) != 0 {
+		t.Errorf("dry run applied to %q", r.applied)
+	}
+}
+
+func TestRunCleanupResume(t *testing.T) {
+	setupCleanup(t)
+
+	input := filepath.Join(t.TempDir(), "tweets.txt")
+	refs := "https://twitter.com/alice/status/1\n2\n# comment\n\nhttps://x.com/alice/status/3?s=20\n4\n2\n"
+	if err := os.WriteFile(input, []byte(refs), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cleanupInput = input
+
+	// Tweet 1 was deleted by an interrupted run.
+	if err := os.WriteFile(checkpoint, []byte("1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &cleanupRecorder{fail: map[string]bool{"https://twitter.com/i/web/status/4": true}}
+	runCleanup(r.job())
+
+	want := []string{
+		"https://twitter.com/i/web/status/2",
+		"https://twitter.com/alice/status/3",
+		"https://twitter.com/i/web/status/4",
+	}
+	if !reflect.DeepEqual(r.applied, want) {
+		t.Fatalf("first run applied to %q, want %q", r.applied, want)
+	}
+
+	done, err := readCheckpoint(checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(done, map[string]bool{"1": true, "2": true, "3": true}) {
+		t.Errorf("checkpoint after a failure = %v, want 1, 2 and 3", done)
+	}
+
+	// The second run only retries the failed tweet and completes.
+	r = &cleanupRecorder{}
+	runCleanup(r.job())
+
+	if len(r.applied) != 1 || !strings.HasSuffix(r.applied[0], "/status/4") {
+		t.Errorf("second run applied to %q, want tweet 4 only", r.applied)
+	}
+
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Errorf("the checkpoint of a completed run was kept: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+// setCompose applies the compose flags shared by the tweet and quote commands
+// to tweetHub, validating them before any browser is started.
+func setCompose() {
+	if len(altTexts) > len(mediaPaths) {
+		cobra.CheckErr(fmt.Errorf("got %d --alt values for %d --media files", len(altTexts), len(mediaPaths)))
+	}
+
+	media := make([]tweethub.Media, len(mediaPaths))
+	for i, path := range mediaPaths {
+		media[i].Path = path
+		if i < len(altTexts) {
+			media[i].Alt = altTexts[i]
+		}
+	}
+
+	cobra.CheckErr(tweethub.ValidateMedia(media))
+	tweetHub.SetMedia(media)
+
+	if sensitive && len(media) == 0 {
+		cobra.CheckErr(fmt.Errorf("--sensitive requires at least one --media file"))
+	}
+	tweetHub.SetSensitive(sensitive)
+
+	if replySettings != "" {
+		settings, err := tweethub.ParseReplySettings(replySettings)
+		cobra.CheckErr(err)
+		tweetHub.SetReplySettings(settings)
+	}
+
+	if scheduleAt != "" {
+		at, err := tweethub.ParseScheduleTime(scheduleAt)
+		cobra.CheckErr(err)
+		tweetHub.SetScheduleAt(at)
+	}
+}
+
+// setPoll builds the poll from the tweet command flags, validates it and
+// sets it on tweetHub. It does nothing when no poll option was given.
+func setPoll() {
+	if len(pollOptions) == 0 {
+		return
+	}
+
+	if len(mediaPaths) > 0 {
+		cobra.CheckErr(fmt.Errorf("a tweet cannot have both media and a poll"))
+	}
+
+	duration, err := tweethub.ParsePollDuration(pollDuration)
+	cobra.CheckErr(err)
+
+	poll := &tweethub.Poll{Options: pollOptions, Duration: duration}
+	cobra.CheckErr(poll.Validate())
+
+	tweetHub.SetPoll(poll)
+}
+
+// addComposeFlags registers the compose flags shared by the tweet and quote commands.
+func addComposeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&messageFile, "message-file", "", `Read the message from a file, or from standard input with "-".`)
+	cmd.Flags().BoolVar(&edit, "edit", false, "Write the message in $EDITOR; lines below the scissors line of the template are ignored.")
+	cmd.Flags().StringArrayVar(&mediaPaths, "media", nil, "Attach an image, GIF or video (repeatable).")
+	cmd.Flags().StringArrayVar(&altTexts, "alt", nil, "Alt text for the media file in the same position (repeatable).")
+	cmd.Flags().StringVar(&replySettings, "reply-settings", "", "Who can reply: everyone, following or mentioned.")
+	cmd.Flags().BoolVar(&sensitive, "sensitive", false, "Mark the attached media as sensitive content.")
+	cmd.Flags().StringVar(&scheduleAt, "schedule-at", "", "Schedule the post instead of posting now (YYYY-MM-DD HH:MM or RFC 3339).")
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/store"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	since string
+	until string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export data of a configured account.",
+	Long: `The export command reads data of a configured account from the Twitter web interface
+and writes it to a file or standard output, so it can be kept as a backup.`,
+}
+
+// exportTweetsCmd represents the export tweets command
+var exportTweetsCmd = &cobra.Command{
+	Use:   "tweets",
+	Short: "Export the tweets of an account as JSONL.",
+	Long: `The tweets command scrolls the profile timeline of the account and writes one JSON
+object per line for every tweet it posted, with its media links and counts. Reposts
+of other accounts are skipped. Dates are YYYY-MM-DD or RFC 3339 times and both bounds
+are inclusive; "--until" dates include the whole day.
+
+Examples:
+- Export every tweet of an account:
+  tweethub export tweets --account <username> --output tweets.jsonl
+
+- Export the tweets of January 2024:
+  tweethub export tweets --account <username> --since 2024-01-01 --until 2024-01-31`,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := dateRange()
+
+		useAccount(singleAccount())
+
+		if dryRun {
+			fmt.Printf("[dry-run] @%s: export tweets to %s\n", tweetHub.Username(), output)
+			return
+		}
+
+		out, err := createOutput(output)
+		cobra.CheckErr(err)
+		defer out.Close()
+
+		s, cancel, err := tweetHub.NewSession()
+		defer cancel()
+		cobra.CheckErr(err)
+
+		enc := json.NewEncoder(out)
+
+		timeline := store.Timeline{Account: s.Username(), ExportedAt: time.Now().UTC()}
+
+		var writeErr error
+
+		err = s.Timeline(s.Username(), func(post tweethub.Post) bool {
+			switch {
+			case (!from.IsZero() || !to.IsZero()) && post.CreatedAt.IsZero():
+				// Undated posts can't be placed in the range, but don't end it.
+				return true
+			case !to.IsZero() && post.CreatedAt.After(to):
+				return true
+			case !from.IsZero() && post.CreatedAt.Before(from):
+				// The timeline is sorted newest first, except for a pinned tweet.
+				return post.Pinned
+			}
+
+			if writeErr = enc.Encode(post); writeErr != nil {
+				return false
+			}
+			timeline.Posts = append(timeline.Posts, post)
+
+			return true
+		})
+		cobra.CheckErr(err)
+		cobra.CheckErr(writeErr)
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d tweets of @%s\n", len(timeline.Posts), s.Username())
+
+		record(func(db *store.Store) error {
+			return db.RecordTimeline(timeline)
+		})
+	},
+}
+
+// dateRange parses --since and --until. Zero times mean no bound.
+func dateRange() (from, to time.Time) {
+	var err error
+
+	if since != "" {
+		from, err = parseDate(since, false)
+		cobra.CheckErr(err)
+	}
+
+	if until != "" {
+		to, err = parseDate(until, true)
+		cobra.CheckErr(err)
+	}
+
+	return from, to
+}
+
+// parseDate parses a YYYY-MM-DD date in the local time zone or an RFC 3339
+// time. When end is set, a date stands for the last instant of that day.
+func parseDate(s string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or RFC 3339", s)
+	}
+
+	if end {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	return t, nil
+}
+
+func init() {
+	exportTweetsCmd.Flags().StringVar(&since, "since", "", "Only export tweets created on or after this date.")
+	exportTweetsCmd.Flags().StringVar(&until, "until", "", "Only export tweets created on or before this date.")
+	exportTweetsCmd.Flags().StringVarP(&output, "output", "o", "-", `Output file, "-" for standard output.`)
+
+	exportCmd.AddCommand(exportTweetsCmd)
+
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/store"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotDir    string
+	snapshotOutput string
+	diffFormat     string
+	snapshotKind   string
+)
+
+// snapshotDiff lists the accounts that appeared and disappeared between two snapshots.
+type snapshotDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// exportFollowersCmd represents the export followers command
+var exportFollowersCmd = &cobra.Command{
+	Use:   "followers",
+	Short: "Export a snapshot of the followers of an account.",
+	Long: `The followers command writes the followers of the account to a timestamped JSON
+snapshot, e.g. <username>-followers-20240131T120000.json, that can later be compared
+with "tweethub followers diff".
+
+Examples:
+- Take a snapshot of the followers:
+  tweethub export followers --account <username> --dir snapshots`,
+	Run: func(cmd *cobra.Command, args []string) {
+		exportSnapshot("followers", (*tweethub.Session).Followers)
+	},
+}
+
+// exportFollowingCmd represents the export following command
+var exportFollowingCmd = &cobra.Command{
+	Use:   "following",
+	Short: "Export a snapshot of the accounts an account follows.",
+	Long: `The following command writes the accounts followed by the account to a timestamped
+JSON snapshot, e.g. <username>-following-20240131T120000.json.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		exportSnapshot("following", (*tweethub.Session).Following)
+	},
+}
+
+// followersCmd represents the followers command
+var followersCmd = &cobra.Command{
+	Use:   "followers",
+	Short: "Work with follower snapshots.",
+}
+
+// followersDiffCmd represents the followers diff command
+var followersDiffCmd = &cobra.Command{
+	Use:   "diff [<old> <new>]",
+	Short: "Report who followed and unfollowed between two snapshots.",
+	Long: `The diff command compares two snapshots written by "tweethub export followers"
+(or "export following") and lists the accounts that were added and removed.
+
+Without arguments, the last two snapshots of the account kept in the history store
+are compared; "--kind following" selects the snapshots of followed accounts.
+
+Examples:
+- Compare two snapshots:
+  tweethub followers diff snapshots/brand-followers-20240101T090000.json snapshots/brand-followers-20240201T090000.json
+
+- Compare the last two snapshots of an account:
+  tweethub followers diff --account <username>`,
+	Annotations: optionalAccounts,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return fmt.Errorf("accepts 0 or 2 arg(s), received %d", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if diffFormat != "text" && diffFormat != "json" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected text or json", diffFormat))
+		}
+
+		var before, after store.Snapshot
+		var err error
+
+		if len(args) == 2 {
+			before, err = readSnapshot(args[0])
+			cobra.CheckErr(err)
+
+			after, err = readSnapshot(args[1])
+			cobra.CheckErr(err)
+		} else {
+			before, after = lastSnapshots()
+		}
+
+		if !strings.EqualFold(before.Account, after.Account) || before.Kind != after.Kind {
+			fmt.Fprintf(os.Stderr, "Warning: comparing %s of @%s with %s of @%s\n", before.Kind, before.Account, after.Kind, after.Account)
+		}
+
+		diff := diffSnapshots(before, after)
+
+		if diffFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			cobra.CheckErr(enc.Encode(diff))
+			return
+		}
+
+		for _, username := range diff.Added {
+			fmt.Printf("+ @%s\n", username)
+		}
+		for _, username := range diff.Removed {
+			fmt.Printf("- @%s\n", username)
+		}
+
+		fmt.Printf("%d added, %d removed between %s and %s (%d -> %d)\n",
+			len(diff.Added), len(diff.Removed),
+			before.TakenAt.Local().Format("2006-01-02 15:04"), after.TakenAt.Local().Format("2006-01-02 15:04"),
+			len(before.Users), len(after.Users))
+	},
+}
+
+// exportSnapshot takes a snapshot of the given kind for --account with list
+// and writes it to --output, or to a timestamped file in --dir.
+func exportSnapshot(kind string, list func(*tweethub.Session, string) ([]tweethub.User, error)) {
+	useAccount(singleAccount())
+
+	snap := store.Snapshot{
+		Account: tweetHub.Username(),
+		Kind:    kind,
+		TakenAt: time.Now().UTC(),
+	}
+
+	path := snapshotOutput
+	if path == "" {
+		path = filepath.Join(snapshotDir, fmt.Sprintf("%s-%s-%s.json", snap.Account, kind, snap.TakenAt.Format("20060102T150405")))
+	}
+
+	if dryRun {
+		fmt.Printf("[dry-run] @%s: export %s to %s\n", snap.Account, kind, path)
+		return
+	}
+
+	s, cancel, err := tweetHub.NewSession()
+	defer cancel()
+	cobra.CheckErr(err)
+
+	snap.Users, err = list(s, s.Username())
+	cobra.CheckErr(err)
+
+	if snapshotOutput == "" {
+		cobra.CheckErr(os.MkdirAll(snapshotDir, 0o755))
+	}
+
+	out, err := createOutput(path)
+	cobra.CheckErr(err)
+	defer out.Close()
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	cobra.CheckErr(enc.Encode(snap))
+
+	fmt.Fprintf(os.Stderr, "Saved %d %s of @%s to %s\n", len(snap.Users), kind, snap.Account, path)
+
+	record(func(db *store.Store) error {
+		return db.RecordSnapshot(snap)
+	})
+}
+
+// lastSnapshots returns the last two snapshots of --kind for the selected
+// account from the history store.
+func lastSnapshots() (before, after store.Snapshot) {
+	account := singleAccount().Username
+
+	if snapshotKind != "followers" && snapshotKind != "following" {
+		cobra.CheckErr(fmt.Errorf("invalid kind %q, expected followers or following", snapshotKind))
+	}
+
+	db, err := openStore()
+	cobra.CheckErr(err)
+
+	snaps, err := db.Snapshots(account, snapshotKind)
+	cobra.CheckErr(err)
+
+	if len(snaps) < 2 {
+		cobra.CheckErr(fmt.Errorf("the history store has %d %s snapshots of @%s, at least 2 are needed", len(snaps), snapshotKind, account))
+	}
+
+	return snaps[len(snaps)-2], snaps[len(snaps)-1]
+}
+
+// readSnapshot reads a snapshot written by exportSnapshot.
+func readSnapshot(path string) (store.Snapshot, error) {
+	var snap store.Snapshot
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snap, err
+	}
+
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	return snap, nil
+}
+
+// diffSnapshots compares the usernames of two snapshots, ignoring case.
+func diffSnapshots(before, after store.Snapshot) snapshotDiff {
+	return snapshotDiff{
+		Added:   missingUsers(after.Users, before.Users),
+		Removed: missingUsers(before.Users, after.Users),
+	}
+}
+
+// missingUsers returns the sorted usernames of users that are not in others.
+func missingUsers(users, others []tweethub.User) []string {
+	known := make(map[string]bool)
+	for _, user := range others {
+		known[strings.ToLower(user.Username)] = true
+	}
+
+	var missing []string
+	for _, user := range users {
+		if !known[strings.ToLower(user.Username)] {
+			missing = append(missing, user.Username)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{exportFollowersCmd, exportFollowingCmd} {
+		cmd.Flags().StringVar(&snapshotDir, "dir", ".", "Directory for the timestamped snapshot file.")
+		cmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", `Write the snapshot to this file instead, "-" for standard output.`)
+
+		exportCmd.AddCommand(cmd)
+	}
+
+	followersDiffCmd.Flags().StringVar(&diffFormat, "format", "text", "Output format: text or json.")
+	followersDiffCmd.Flags().StringVar(&snapshotKind, "kind", "followers", "Kind of the stored snapshots: followers or following.")
+
+	followersCmd.AddCommand(followersDiffCmd)
+
+	rootCmd.AddCommand(followersCmd)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/alomia/tweethub-cli/internal/store"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	users := func(usernames ...string) []tweethub.User {
+		var list []tweethub.User
+		for _, username := range usernames {
+			list = append(list, tweethub.User{Username: username})
+		}
+		return list
+	}
+
+	tests := []struct {
+		name   string
+		before []tweethub.User
+		after  []tweethub.User
+		want   snapshotDiff
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "unchanged",
+			before: users("alice", "bob"),
+			after:  users("bob", "alice"),
+		},
+		{
+			name:   "added and removed",
+			before: users("alice", "bob", "carol"),
+			after:  users("dave", "alice", "bea"),
+			want:   snapshotDiff{Added: []string{"bea", "dave"}, Removed: []string{"bob", "carol"}},
+		},
+		{
+			name:   "renamed case",
+			before: users("Alice"),
+			after:  users("alice"),
+		},
+		{
+			name:  "first snapshot",
+			after: users("bob", "alice"),
+			want:  snapshotDiff{Added: []string{"alice", "bob"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffSnapshots(store.Snapshot{Users: tt.before}, store.Snapshot{Users: tt.after})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffSnapshots() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "brand-followers.json")
+	data := `{"account":"brand","kind":"followers","taken_at":"2024-01-31T12:00:00Z","users":[{"username":"alice"}]}`
+	if err := os.WriteFile(valid, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := readSnapshot(valid)
+	if err != nil {
+		t.Fatalf("readSnapshot() error = %v", err)
+	}
+	if snap.Account != "brand" || snap.Kind != "followers" || len(snap.Users) != 1 || snap.Users[0].Username != "alice" {
+		t.Errorf("readSnapshot() = %+v", snap)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("[]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readSnapshot(invalid); err == nil {
+		t.Error("readSnapshot() of an invalid file succeeded")
+	}
+
+	if _, err := readSnapshot(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("readSnapshot() of a missing file succeeded")
+	}
+}
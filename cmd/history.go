@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alomia/tweethub-cli/internal/store"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyFormat string
+	historyLimit  int
+	undoLast      bool
+
+	// undoing is the ID of the action being undone by history undo, recorded
+	// with the actions run to undo it.
+	undoing int64
+)
+
+// inverses maps each undoable action to the TweetHub method undoing it,
+// given the target of the action.
+var inverses = map[string]func(t tweethub.TweetHub, target string) context.CancelFunc{
+	"like":       tweethub.TweetHub.UnLike,
+	"unlike":     tweethub.TweetHub.Like,
+	"repost":     tweethub.TweetHub.UnRepost,
+	"unrepost":   tweethub.TweetHub.Repost,
+	"follow":     tweethub.TweetHub.UnFollow,
+	"unfollow":   tweethub.TweetHub.Follow,
+	"bookmark":   tweethub.TweetHub.UnBookmark,
+	"unbookmark": tweethub.TweetHub.Bookmark,
+	"pin":        tweethub.TweetHub.UnPin,
+	"unpin":      tweethub.TweetHub.Pin,
+	"block":      tweethub.TweetHub.UnBlock,
+	"unblock":    tweethub.TweetHub.Block,
+	"mute":       tweethub.TweetHub.UnMute,
+	"unmute":     tweethub.TweetHub.Mute,
+	"tweet":      tweethub.TweetHub.UnTweet,
+}
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the recent actions run on Twitter.",
+	Long: `The history command lists the most recent actions recorded in the history store,
+newest first, with the ID to give to "history undo".
+
+Examples:
+- List the last 20 actions:
+  tweethub history
+
+- List the last 100 actions of an account as JSON:
+  tweethub history --account <username> --limit 100 --format json`,
+	Annotations: optionalAccounts,
+	Run: func(cmd *cobra.Command, args []string) {
+		if historyFormat != "text" && historyFormat != "json" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected text or json", historyFormat))
+		}
+
+		actions := recentActions()
+		undone := undoneActions(actions)
+
+		if historyFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			cobra.CheckErr(enc.Encode(actions))
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTIME\tACCOUNT\tACTION\tTARGET\tRESULT")
+		for _, a := range actions {
+			result := a.Result
+			if id, ok := undone[a.ID]; ok {
+				result += fmt.Sprintf(" (undone by %d)", id)
+			}
+			fmt.Fprintf(w, "%d\t%s\t@%s\t%s\t%s\t%s\n", a.ID, a.Time.Local().Format("2006-01-02 15:04"), a.Account, a.Action, actionTarget(a), result)
+		}
+		w.Flush()
+	},
+}
+
+// historyUndoCmd represents the history undo command
+var historyUndoCmd = &cobra.Command{
+	Use:   "undo [<id>]",
+	Short: "Undo a recorded action.",
+	Long: `The undo command runs the inverse of a recorded action, such as an unlike for a like
+or deleting a created tweet, as the same account and on the same target. Only
+successful actions can be undone, and only once; deletions and the actions run
+by undo cannot.
+
+Examples:
+- Undo the action with ID 42:
+  tweethub history undo 42
+
+- Undo the last action that can still be undone:
+  tweethub history undo --last`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if undoLast == (len(args) == 1) {
+			cobra.CheckErr(fmt.Errorf("give either an action ID or --last"))
+		}
+
+		db, err := openStore()
+		cobra.CheckErr(err)
+
+		actions, err := db.Actions()
+		cobra.CheckErr(err)
+
+		undone := undoneActions(actions)
+
+		var action *store.Action
+
+		if undoLast {
+			for i := len(actions) - 1; i >= 0; i-- {
+				if undoable(actions[i], undone) == nil {
+					action = &actions[i]
+					break
+				}
+			}
+
+			if action == nil {
+				cobra.CheckErr(fmt.Errorf("no action left to undo in the history store"))
+			}
+		} else {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				cobra.CheckErr(fmt.Errorf("invalid action ID %q", args[0]))
+			}
+
+			for i := range actions {
+				if actions[i].ID == id {
+					action = &actions[i]
+				}
+			}
+		}
+
+		if action == nil {
+			cobra.CheckErr(fmt.Errorf("action not found in the history store"))
+		}
+
+		cobra.CheckErr(undoable(*action, undone))
+
+		inverse := inverses[action.Action]
+		target := actionTarget(*action)
+
+		useAccount(findAccount(action.Account))
+		undoing = action.ID
+
+		fmt.Fprintf(os.Stderr, "Undoing %s of %s as @%s\n", action.Action, target, action.Account)
+
+		cancel := inverse(*tweetHub, target)
+		defer cancel()
+	},
+}
+
+// undoable returns why action cannot be undone, given the actions already
+// undone, or nil when it can.
+func undoable(action store.Action, undone map[int64]int64) error {
+	if _, ok := inverses[action.Action]; !ok {
+		return fmt.Errorf("action %d (%s) cannot be undone", action.ID, action.Action)
+	}
+
+	if action.Undoes != 0 {
+		return fmt.Errorf("action %d (%s) undid action %d and cannot be undone", action.ID, action.Action, action.Undoes)
+	}
+
+	if action.Result != tweethub.OutcomeSuccess {
+		return fmt.Errorf("action %d (%s) did not change anything: %s", action.ID, action.Action, action.Result)
+	}
+
+	if id, ok := undone[action.ID]; ok {
+		return fmt.Errorf("action %d (%s) was already undone by action %d", action.ID, action.Action, id)
+	}
+
+	if actionTarget(action) == "" {
+		return fmt.Errorf("action %d (%s) has no known target", action.ID, action.Action)
+	}
+
+	return nil
+}
+
+// undoneActions maps the ID of each action undone successfully to the ID
+// of the action that undid it.
+func undoneActions(actions []store.Action) map[int64]int64 {
+	undone := make(map[int64]int64)
+
+	for _, a := range actions {
+		if a.Undoes != 0 && a.Result == tweethub.OutcomeSuccess {
+			undone[a.Undoes] = a.ID
+		}
+	}
+
+	return undone
+}
+
+// recentActions returns the last --limit actions of the history store,
+// newest first, keeping those of the --account accounts when set.
+func recentActions() []store.Action {
+	db, err := openStore()
+	cobra.CheckErr(err)
+
+	actions, err := db.Actions()
+	cobra.CheckErr(err)
+
+	keep := make(map[string]bool)
+	for _, name := range accountNames {
+		keep[strings.ToLower(strings.TrimPrefix(name, "@"))] = true
+	}
+	if len(accountNames) > 0 {
+		for _, user := range selected {
+			keep[strings.ToLower(user.Username)] = true
+		}
+	}
+
+	var recent []store.Action
+
+	for i := len(actions) - 1; i >= 0 && (historyLimit <= 0 || len(recent) < historyLimit); i-- {
+		if len(keep) == 0 || keep[strings.ToLower(actions[i].Account)] {
+			recent = append(recent, actions[i])
+		}
+	}
+
+	return recent
+}
+
+// actionTarget returns what the action applied to: the created tweet for a
+// tweet, the target otherwise.
+func actionTarget(a store.Action) string {
+	if a.Action == "tweet" {
+		return a.URL
+	}
+
+	return a.Target
+}
+
+func init() {
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "Maximum number of actions to list, 0 for all.")
+	historyCmd.Flags().StringVar(&historyFormat, "format", "text", "Output format: text or json.")
+
+	historyUndoCmd.Flags().BoolVar(&undoLast, "last", false, "Undo the last action that can still be undone.")
+
+	historyCmd.AddCommand(historyUndoCmd)
+
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alomia/tweethub-cli/internal/store"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+)
+
+func TestUndoable(t *testing.T) {
+	undone := map[int64]int64{3: 4}
+
+	tests := []struct {
+		name    string
+		action  store.Action
+		wantErr string
+	}{
+		{
+			name:   "like",
+			action: store.Action{ID: 1, Action: "like", Target: "https://twitter.com/bob/status/1", Result: tweethub.OutcomeSuccess},
+		},
+		{
+			name:   "tweet with URL",
+			action: store.Action{ID: 2, Action: "tweet", URL: "https://twitter.com/alice/status/2", Result: tweethub.OutcomeSuccess},
+		},
+		{
+			name:    "tweet without URL",
+			action:  store.Action{ID: 2, Action: "tweet", Result: tweethub.OutcomeSuccess},
+			wantErr: "has no known target",
+		},
+		{
+			name:    "already undone",
+			action:  store.Action{ID: 3, Action: "follow", Target: "bob", Result: tweethub.OutcomeSuccess},
+			wantErr: "was already undone by action 4",
+		},
+		{
+			name:    "undo of another action",
+			action:  store.Action{ID: 4, Action: "unfollow", Target: "bob", Result: tweethub.OutcomeSuccess, Undoes: 3},
+			wantErr: "undid action 3",
+		},
+		{
+			name:    "skipped",
+			action:  store.Action{ID: 5, Action: "block", Target: "bob", Result: tweethub.OutcomeSkipped},
+			wantErr: "did not change anything: skipped",
+		},
+		{
+			name:    "failed",
+			action:  store.Action{ID: 6, Action: "mute", Target: "bob", Result: tweethub.OutcomeFailed},
+			wantErr: "did not change anything: failed",
+		},
+		{
+			name:    "no inverse",
+			action:  store.Action{ID: 7, Action: "quote", Target: "https://twitter.com/bob/status/1", Result: tweethub.OutcomeSuccess},
+			wantErr: "cannot be undone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := undoable(tt.action, undone)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("undoable() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("undoable() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUndoneActions(t *testing.T) {
+	actions := []store.Action{
+		{ID: 1, Action: "like", Result: tweethub.OutcomeSuccess},
+		{ID: 2, Action: "unlike", Result: tweethub.OutcomeFailed, Undoes: 1},
+		{ID: 3, Action: "follow", Result: tweethub.OutcomeSuccess},
+		{ID: 4, Action: "unfollow", Result: tweethub.OutcomeSuccess, Undoes: 3},
+		{ID: 5, Action: "unlike", Result: tweethub.OutcomeSuccess, Undoes: 1},
+	}
+
+	want := map[int64]int64{1: 5, 3: 4}
+	if got := undoneActions(actions); !reflect.DeepEqual(got, want) {
+		t.Errorf("undoneActions() = %v, want %v", got, want)
+	}
+}
+
+func TestInverses(t *testing.T) {
+	for action := range inverses {
+		if action == "tweet" {
+			continue
+		}
+
+		opposite := "un" + action
+		if strings.HasPrefix(action, "un") {
+			opposite = strings.TrimPrefix(action, "un")
+		}
+
+		if _, ok := inverses[opposite]; !ok {
+			t.Errorf("%s can be undone but %s cannot", action, opposite)
+		}
+	}
+}
@@ -25,8 +25,8 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		switch {
 		case undo:
-			if allAccounts {
-				for _, user := range accounts {
+			if len(selected) > 1 {
+				for _, user := range selected {
 					tweetHub.SetUsername(user.Username)
 					tweetHub.SetPassword(user.Password)
 
@@ -38,8 +38,8 @@ Examples:
 			cancel := tweetHub.UnLike(url)
 			defer cancel()
 		default:
-			if allAccounts {
-				for _, user := range accounts {
+			if len(selected) > 1 {
+				for _, user := range selected {
 					tweetHub.SetUsername(user.Username)
 					tweetHub.SetPassword(user.Password)
 
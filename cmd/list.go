@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	listDescription string
+	listPrivate     bool
+	membersFormat   string
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Manage the Twitter Lists of the account.",
+	Long: `The list command allows you to create, rename and delete the Twitter Lists of the account
+and to manage their members. Lists are identified by their name, ID or URL.
+
+Examples:
+- Create a private list:
+  tweethub list create "Partners" --description "Partner accounts" --private
+
+- Add a member to a list:
+  tweethub list add "Partners" --username <target-username>
+
+- Export the members of a list as CSV:
+  tweethub list export "Partners" --output partners.csv`,
+}
+
+// listCreateCmd represents the list create command
+var listCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a list.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		cancel := tweetHub.CreateList(args[0], listDescription, listPrivate)
+		defer cancel()
+	},
+}
+
+// listDeleteCmd represents the list delete command
+var listDeleteCmd = &cobra.Command{
+	Use:   "delete <list>",
+	Short: "Delete a list.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		cancel := tweetHub.DeleteList(args[0])
+		defer cancel()
+	},
+}
+
+// listRenameCmd represents the list rename command
+var listRenameCmd = &cobra.Command{
+	Use:   "rename <list> <new-name>",
+	Short: "Rename a list.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		cancel := tweetHub.RenameList(args[0], args[1])
+		defer cancel()
+	},
+}
+
+// listAddCmd represents the list add command
+var listAddCmd = &cobra.Command{
+	Use:   "add <list>",
+	Short: "Add a user to a list.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		cancel := tweetHub.AddListMember(args[0], username)
+		defer cancel()
+	},
+}
+
+// listRemoveCmd represents the list remove command
+var listRemoveCmd = &cobra.Command{
+	Use:   "remove <list>",
+	Short: "Remove a user from a list.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		cancel := tweetHub.RemoveListMember(args[0], username)
+		defer cancel()
+	},
+}
+
+// listExportCmd represents the list export command
+var listExportCmd = &cobra.Command{
+	Use:   "export <list>",
+	Short: "Export the members of a list.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		if membersFormat != "csv" && membersFormat != "json" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected csv or json", membersFormat))
+		}
+
+		if plannedRead(fmt.Sprintf("the members of list %q", args[0])) {
+			return
+		}
+
+		members, cancel, err := tweetHub.ListMembers(args[0])
+		defer cancel()
+		cobra.CheckErr(err)
+
+		out, err := createOutput(output)
+		cobra.CheckErr(err)
+		defer out.Close()
+
+		if membersFormat == "json" {
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			cobra.CheckErr(enc.Encode(members))
+			return
+		}
+
+		cw := csv.NewWriter(out)
+		cw.Write([]string{"username", "name"})
+		for _, member := range members {
+			cw.Write([]string{member.Username, member.Name})
+		}
+		cw.Flush()
+		cobra.CheckErr(cw.Error())
+	},
+}
+
+func init() {
+	listCreateCmd.Flags().StringVar(&listDescription, "description", "", "Description of the list.")
+	listCreateCmd.Flags().BoolVar(&listPrivate, "private", false, "Make the list private.")
+
+	for _, cmd := range []*cobra.Command{listAddCmd, listRemoveCmd} {
+		cmd.Flags().StringVarP(&username, "username", "u", "", "Specify the target Twitter username.")
+		cmd.MarkFlagRequired("username")
+	}
+
+	listExportCmd.Flags().StringVar(&membersFormat, "format", "csv", "Output format: csv or json.")
+	listExportCmd.Flags().StringVarP(&output, "output", "o", "-", `Output file, "-" for standard output.`)
+
+	listCmd.AddCommand(listCreateCmd)
+	listCmd.AddCommand(listDeleteCmd)
+	listCmd.AddCommand(listRenameCmd)
+	listCmd.AddCommand(listAddCmd)
+	listCmd.AddCommand(listRemoveCmd)
+	listCmd.AddCommand(listExportCmd)
+
+	rootCmd.AddCommand(listCmd)
+}
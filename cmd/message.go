@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+// readMessage fills message from --message-file or the editor when requested.
+// A --message-file of "-" reads the message from standard input.
+func readMessage() {
+	switch {
+	case messageFile != "" && edit:
+		cobra.CheckErr(fmt.Errorf("--message-file and --edit cannot be used together"))
+	case messageFile != "" && message != "":
+		cobra.CheckErr(fmt.Errorf("--message-file and --message cannot be used together"))
+	case messageFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		cobra.CheckErr(err)
+		message = strings.TrimRight(string(data), "\n")
+	case messageFile != "":
+		data, err := os.ReadFile(messageFile)
+		cobra.CheckErr(err)
+		message = strings.TrimRight(string(data), "\n")
+	case edit:
+		text, err := editMessage(message)
+		cobra.CheckErr(err)
+		message = text
+	}
+}
+
+// scissors separates the message from the template comments in the editor
+// buffer. Like in git commit messages, it and everything below it are
+// removed, so lines of the message may start with "#", e.g. hashtags.
+const scissors = "# ------------------------ >8 ------------------------"
+
+// editMessage opens $VISUAL or $EDITOR on a template holding initial, the
+// target account and the character count when the editor is opened. The
+// count of the edited message is printed once the editor exits, the editor
+// is opened again while the message is too long, and an empty buffer aborts.
+func editMessage(initial string) (string, error) {
+	file, err := os.CreateTemp("", "tweethub-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	file.Close()
+
+	text := initial
+
+	for {
+		if err := os.WriteFile(file.Name(), []byte(messageTemplate(text)), 0o600); err != nil {
+			return "", err
+		}
+
+		if err := runEditor(file.Name()); err != nil {
+			return "", err
+		}
+
+		data, err := os.ReadFile(file.Name())
+		if err != nil {
+			return "", err
+		}
+
+		text = stripComments(string(data))
+
+		if text == "" {
+			return "", fmt.Errorf("aborting due to empty message")
+		}
+
+		length := tweethub.Length(text)
+		if length <= tweethub.MaxTweetLength {
+			fmt.Fprintf(os.Stderr, "Message length: %d/%d characters\n", length, tweethub.MaxTweetLength)
+			return text, nil
+		}
+
+		fmt.Fprintf(os.Stderr, "Message length: %d/%d characters, too long, reopening the editor\n", length, tweethub.MaxTweetLength)
+	}
+}
+
+// messageTemplate returns the editor buffer for text.
+func messageTemplate(text string) string {
+	usernames := make([]string, len(selected))
+	for i, user := range selected {
+		usernames[i] = "@" + user.Username
+	}
+	target := strings.Join(usernames, ", ")
+
+	length := tweethub.Length(text)
+	status := ""
+	if length > tweethub.MaxTweetLength {
+		status = " (too long, shorten the message)"
+	}
+
+	return fmt.Sprintf(`%s
+
+%s
+# Write your message above this line; it and everything below it are ignored.
+# Account: %s
+# Length: %d/%d characters when the editor was opened%s
+# Leave the message empty to abort.
+`, text, scissors, target, length, tweethub.MaxTweetLength, status)
+}
+
+// stripComments removes the scissors line, the template comments below it
+// and the blank lines around the message.
+func stripComments(buffer string) string {
+	var lines []string
+
+	scanner := bufio.NewScanner(strings.NewReader(buffer))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == scissors {
+			break
+		}
+		lines = append(lines, scanner.Text())
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// runEditor opens path in the user's editor and waits for it to exit.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	args := strings.Fields(editor)
+
+	editorCmd := exec.Command(args[0], append(args[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("running editor %q: %v", editor, err)
+	}
+
+	return nil
+}
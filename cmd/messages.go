@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// messageData is the data available to the templates in the messages list.
+type messageData struct {
+	Date    time.Time
+	Account string
+	Label   string
+	Tags    []string
+	URL     string
+	Vars    map[string]string
+}
+
+// messageFuncs are the helpers available to the templates in the messages list.
+var messageFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"pick": func(choices ...string) string {
+		if len(choices) == 0 {
+			return ""
+		}
+		return choices[rand.Intn(len(choices))]
+	},
+	"default": func(fallback, value string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	},
+	"hashtag": func(s string) string {
+		return "#" + strings.Join(strings.Fields(s), "")
+	},
+}
+
+// messagesCmd represents the messages command
+var messagesCmd = &cobra.Command{
+	Use:   "messages",
+	Short: "Work with the predefined messages from the configuration file.",
+	Long: `The messages command works with the "messages" list of the configuration file.
+
+Each message is a Go text/template rendered before it is posted with "--use-messages".
+Templates can use the following fields:
+  {{.Date}}     the current time, in the timezone of the account when it has one
+  {{.Account}}  the username of the account posting the message
+  {{.Label}}    the label of the account
+  {{.Tags}}     the default tags of the account, e.g. {{range .Tags}}{{hashtag .}} {{end}}
+  {{.URL}}      the URL of the target tweet, when there is one
+  {{.Vars}}     the "vars" map of the configuration file, e.g. {{.Vars.campaign}}
+
+and the helpers upper, lower, trim, date, pick, default and hashtag, e.g.
+  {{date "2006-01-02" .Date}}  {{pick "Hi" "Hello"}}  {{hashtag .Vars.event}}`,
+}
+
+// messagesRenderCmd represents the messages render command
+var messagesRenderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Preview the rendered predefined messages.",
+	Long: `The render command renders every message of the configuration file and prints it
+together with its length, so templates can be checked before posting.
+
+Examples:
+- Preview the messages for the first account:
+  tweethub messages render
+
+- Preview the messages for every account and a target tweet:
+  tweethub messages render --all-accounts --url <tweet-url>`,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, user := range selected {
+			for i, text := range viper.GetStringSlice("messages") {
+				rendered, err := renderMessage(text, user.Username, url)
+				if err != nil {
+					fmt.Printf("@%s message %d: %v\n", user.Username, i, err)
+					continue
+				}
+
+				fmt.Printf("@%s message %d (%d/%d characters):\n%s\n\n", user.Username, i, tweethub.Length(rendered), tweethub.MaxTweetLength, rendered)
+			}
+		}
+	},
+}
+
+// pickMessage returns the first predefined message, or a random one when
+// --random is given, rendered for the given account and target URL.
+func pickMessage(account, targetURL string) string {
+	messages := viper.GetStringSlice("messages")
+	if len(messages) == 0 {
+		cobra.CheckErr(fmt.Errorf("no messages found in the configuration file"))
+	}
+
+	text := messages[0]
+	if random {
+		text = messages[rand.Intn(len(messages))]
+	}
+
+	rendered, err := renderMessage(text, account, targetURL)
+	cobra.CheckErr(err)
+
+	return rendered
+}
+
+// renderMessage executes text as a message template and checks the length of the result.
+func renderMessage(text, account, targetURL string) (string, error) {
+	tmpl, err := template.New("message").Funcs(messageFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	data := messageData{
+		Date:    time.Now(),
+		Account: account,
+		URL:     targetURL,
+		Vars:    viper.GetStringMapString("vars"),
+	}
+
+	if user, err := lookupAccount(account); err == nil {
+		data.Label, data.Tags = user.Label, user.Tags
+
+		if user.Timezone != "" {
+			loc, err := time.LoadLocation(user.Timezone)
+			if err != nil {
+				return "", fmt.Errorf("timezone of @%s: %v", user.Username, err)
+			}
+			data.Date = data.Date.In(loc)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	rendered := buf.String()
+	if length := tweethub.Length(rendered); length > tweethub.MaxTweetLength {
+		return "", fmt.Errorf("rendered message is %d characters long, the limit is %d", length, tweethub.MaxTweetLength)
+	}
+
+	return rendered, nil
+}
+
+func init() {
+	messagesRenderCmd.Flags().StringVar(&url, "url", "", "Target tweet URL available to the templates as {{.URL}}.")
+	messagesRenderCmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "Render the messages for all accounts")
+
+	messagesCmd.AddCommand(messagesRenderCmd)
+
+	rootCmd.AddCommand(messagesCmd)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRenderMessage(t *testing.T) {
+	saved := accounts
+	t.Cleanup(func() {
+		accounts = saved
+		viper.Set("vars", nil)
+	})
+
+	accounts = []Account{
+		{Username: "alice", Alias: "main", Label: "Main", Tags: []string{"go lang", "cli"}, Timezone: "America/Bogota"},
+		{Username: "bob", Timezone: "Not/AZone"},
+	}
+	viper.Set("vars", map[string]string{"event": "go conf"})
+
+	tests := []struct {
+		name    string
+		text    string
+		account string
+		url     string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "plain",
+			text:    "Hello!",
+			account: "alice",
+			want:    "Hello!",
+		},
+		{
+			name:    "account fields",
+			text:    "{{.Account}} ({{.Label}}): {{range .Tags}}{{hashtag .}} {{end}}",
+			account: "alice",
+			want:    "alice (Main): #golang #cli ",
+		},
+		{
+			name:    "vars and url",
+			text:    "{{upper .Vars.event}} {{.URL}}",
+			account: "alice",
+			url:     "https://twitter.com/bob/status/1",
+			want:    "GO CONF https://twitter.com/bob/status/1",
+		},
+		{
+			name:    "default",
+			text:    `{{default "soon" .URL}}`,
+			account: "alice",
+			want:    "soon",
+		},
+		{
+			name:    "timezone",
+			text:    "{{.Date.Location}}",
+			account: "alice",
+			want:    "America/Bogota",
+		},
+		{
+			name:    "unknown account",
+			text:    "{{.Account}} {{.Label}}",
+			account: "carol",
+			want:    "carol ",
+		},
+		{
+			name:    "invalid timezone",
+			text:    "Hello!",
+			account: "bob",
+			wantErr: "timezone of @bob",
+		},
+		{
+			name:    "missing var",
+			text:    "{{.Vars.missing}}",
+			account: "alice",
+			wantErr: "map has no entry for key",
+		},
+		{
+			name:    "syntax error",
+			text:    "{{.Account",
+			account: "alice",
+			wantErr: "unclosed action",
+		},
+		{
+			name:    "too long",
+			text:    strings.Repeat("a", 281),
+			account: "alice",
+			wantErr: "rendered message is 281 characters long",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderMessage(tt.text, tt.account, tt.url)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("renderMessage() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("renderMessage() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("renderMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// muteCmd represents the mute command
+var muteCmd = &cobra.Command{
+	Use:   "mute",
+	Short: "Mute or unmute a Twitter user.",
+	Long: `The mute command allows you to mute or unmute a specified user.
+If the "--undo" flag is provided, it will undo the action, i.e., unmute the user.
+Users that are already in the requested state are skipped.
+
+Examples:
+- Mute a user:
+  tweethub mute --username <target-username>
+
+- Unmute a user:
+  tweethub mute --username <target-username> --undo`,
+	Run: func(cmd *cobra.Command, args []string) {
+		switch {
+		case undo:
+			if len(selected) > 1 {
+				for _, user := range selected {
+					tweetHub.SetUsername(user.Username)
+					tweetHub.SetPassword(user.Password)
+
+					cancel := tweetHub.UnMute(username)
+					cancel()
+				}
+				return
+			}
+			cancel := tweetHub.UnMute(username)
+			defer cancel()
+		default:
+			if len(selected) > 1 {
+				for _, user := range selected {
+					tweetHub.SetUsername(user.Username)
+					tweetHub.SetPassword(user.Password)
+
+					cancel := tweetHub.Mute(username)
+					cancel()
+				}
+				return
+			}
+			cancel := tweetHub.Mute(username)
+			defer cancel()
+		}
+	},
+}
+
+func init() {
+	muteCmd.Flags().StringVarP(&username, "username", "u", "", "Specify the target Twitter username.")
+	muteCmd.Flags().BoolVar(&undo, "undo", false, "Undo the mute action (unmute).")
+	muteCmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "Use all accounts")
+
+	muteCmd.MarkFlagRequired("username")
+
+	rootCmd.AddCommand(muteCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+// mutesCmd represents the mutes command
+var mutesCmd = &cobra.Command{
+	Use:   "mutes",
+	Short: "Export or import the list of muted users.",
+	Long: `The mutes command manages the list of users muted by the account.
+It works like the blocks command, see "tweethub blocks --help" for the list format.
+
+Examples:
+- Export the muted users:
+  tweethub mutes export --format json --output mutes.json
+
+- Mute every user of a list:
+  tweethub mutes import mutes.json`,
+}
+
+// mutesExportCmd represents the mutes export command
+var mutesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the users muted by the account.",
+	Run: func(cmd *cobra.Command, args []string) {
+		exportHandles("muted users", (*tweethub.Session).Muted)
+	},
+}
+
+// mutesImportCmd represents the mutes import command
+var mutesImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Mute every user of a list.",
+	Long: `The import command mutes every user of the list, skipping users that are
+already muted. With "--undo" it unmutes them instead.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		apply, verb := (*tweethub.Session).Mute, "muted"
+		if undo {
+			apply, verb = (*tweethub.Session).UnMute, "unmuted"
+		}
+
+		importHandles(args[0], verb, apply)
+	},
+}
+
+func init() {
+	mutesExportCmd.Flags().StringVar(&handlesFormat, "format", "csv", "Output format: csv or json.")
+	mutesExportCmd.Flags().StringVarP(&output, "output", "o", "-", `Output file, "-" for standard output.`)
+
+	mutesImportCmd.Flags().BoolVar(&undo, "undo", false, "Unmute the users of the list instead.")
+	mutesImportCmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "Use all accounts")
+	mutesImportCmd.Flags().StringVar(&report, "report", "", "Write the per-entry results as JSON to this file.")
+
+	mutesCmd.AddCommand(mutesExportCmd)
+	mutesCmd.AddCommand(mutesImportCmd)
+
+	rootCmd.AddCommand(mutesCmd)
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prune        bool
+	muteDuration string
+	muteScope    []string
+	wordsFormat  string
+)
+
+// muteWordsCmd represents the mute-words command
+var muteWordsCmd = &cobra.Command{
+	Use:   "mute-words",
+	Short: "Manage the muted words of the account.",
+	Long: `The mute-words command manages the muted words settings of the account.
+
+Words can be muted "--duration" forever, 24h, 7d or 30d, and "--scope" selects
+whether they are muted in the home timeline, the notifications or both.
+
+Examples:
+- Mute two words for a week in the home timeline only:
+  tweethub mute-words add spoiler leak --duration 7d --scope home
+
+- Make the muted words match a checked-in list:
+  tweethub mute-words sync muted-words.txt --prune`,
+}
+
+// muteWordsAddCmd represents the mute-words add command
+var muteWordsAddCmd = &cobra.Command{
+	Use:   "add <word>...",
+	Short: "Mute words.",
+	Long: `The add command mutes the words that are not muted yet and updates the duration
+and scope of those that are, when they differ.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		opts := mutedWordOptions()
+
+		s, cancel, err := tweetHub.NewSession()
+		defer cancel()
+		cobra.CheckErr(err)
+
+		cobra.CheckErr(s.AddMutedWords(args, opts, printMutedWordResult))
+	},
+}
+
+// muteWordsRemoveCmd represents the mute-words remove command
+var muteWordsRemoveCmd = &cobra.Command{
+	Use:   "remove <word>...",
+	Short: "Unmute words.",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		s, cancel, err := tweetHub.NewSession()
+		defer cancel()
+		cobra.CheckErr(err)
+
+		cobra.CheckErr(s.RemoveMutedWords(args, printMutedWordResult))
+	},
+}
+
+// muteWordsListCmd represents the mute-words list command
+var muteWordsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the muted words.",
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		if wordsFormat != "text" && wordsFormat != "json" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected text or json", wordsFormat))
+		}
+
+		if plannedRead("muted words") {
+			return
+		}
+
+		s, cancel, err := tweetHub.NewSession()
+		defer cancel()
+		cobra.CheckErr(err)
+
+		words, err := s.MutedWords()
+		cobra.CheckErr(err)
+
+		if wordsFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			cobra.CheckErr(enc.Encode(words))
+			return
+		}
+
+		for _, word := range words {
+			fmt.Println(word.Word)
+		}
+	},
+}
+
+// muteWordsSyncCmd represents the mute-words sync command
+var muteWordsSyncCmd = &cobra.Command{
+	Use:   "sync <file>",
+	Short: "Make the muted words match a list.",
+	Long: `The sync command mutes every word of the file that is not muted yet and updates
+the duration and scope of the muted ones when they differ. Muted words that are
+not in the file are only listed, unless "--prune" is given to unmute them. The
+file holds one word per line; blank lines and lines starting with "#" are ignored.
+
+Examples:
+- Print the planned changes:
+  tweethub mute-words sync muted-words.txt --prune --dry-run
+
+- Mute the words of the file and unmute the others:
+  tweethub mute-words sync muted-words.txt --prune`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		words, err := readWords(args[0])
+		cobra.CheckErr(err)
+
+		opts := mutedWordOptions()
+
+		s, cancel, err := tweetHub.NewSession()
+		defer cancel()
+		cobra.CheckErr(err)
+
+		cobra.CheckErr(s.SyncMutedWords(words, opts, prune, printMutedWordResult))
+	},
+}
+
+// mutedWordOptions builds the muted word options from --duration and --scope.
+func mutedWordOptions() tweethub.MutedWordOptions {
+	duration, err := tweethub.ParseMuteDuration(muteDuration)
+	cobra.CheckErr(err)
+
+	opts := tweethub.MutedWordOptions{Duration: duration}
+
+	for _, scope := range muteScope {
+		switch scope {
+		case "home":
+			opts.HomeTimeline = true
+		case "notifications":
+			opts.Notifications = true
+		default:
+			cobra.CheckErr(fmt.Errorf("invalid scope %q, expected home or notifications", scope))
+		}
+	}
+
+	if !opts.HomeTimeline && !opts.Notifications {
+		cobra.CheckErr(fmt.Errorf("--scope needs at least one of home or notifications"))
+	}
+
+	return opts
+}
+
+// printMutedWordResult reports the outcome of a change to word, an empty
+// result meaning that nothing changed.
+func printMutedWordResult(word, result string, err error) {
+	switch {
+	case err != nil:
+		fmt.Printf("%q: failed (%v)\n", word, err)
+	case result == "":
+		fmt.Printf("%q: skipped\n", word)
+	case result == "kept":
+		fmt.Printf("%q: kept, not in the list (use --prune to unmute it)\n", word)
+	default:
+		fmt.Printf("%q: %s\n", word, result)
+	}
+}
+
+// readWords reads one word per line from path, ignoring blank and comment lines.
+func readWords(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+
+	return words, scanner.Err()
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{muteWordsAddCmd, muteWordsSyncCmd} {
+		cmd.Flags().StringVar(&muteDuration, "duration", "forever", "How long to mute the words: forever, 24h, 7d or 30d.")
+		cmd.Flags().StringSliceVar(&muteScope, "scope", []string{"home", "notifications"}, "Where to mute the words: home, notifications or both.")
+	}
+
+	muteWordsSyncCmd.Flags().BoolVar(&prune, "prune", false, "Unmute the muted words that are not in the file.")
+
+	muteWordsListCmd.Flags().StringVar(&wordsFormat, "format", "text", "Output format: text or json.")
+
+	muteWordsCmd.AddCommand(muteWordsAddCmd)
+	muteWordsCmd.AddCommand(muteWordsRemoveCmd)
+	muteWordsCmd.AddCommand(muteWordsListCmd)
+	muteWordsCmd.AddCommand(muteWordsSyncCmd)
+
+	rootCmd.AddCommand(muteWordsCmd)
+}
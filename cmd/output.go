@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"io"
+	"os"
+)
+
+// nopCloser wraps standard output so that closing it is a no-op.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// createOutput creates the file at path for writing, or returns standard
+// output when path is empty or "-".
+func createOutput(path string) (io.WriteCloser, error) {
+	if path == "" || path == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+
+	return os.Create(path)
+}
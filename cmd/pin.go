@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+// pinCmd represents the pin command
+var pinCmd = &cobra.Command{
+	Use:   "pin",
+	Short: "Pin or unpin a tweet on your profile.",
+	Long: `The pin command allows you to pin one of your tweets to your profile, or unpin it.
+You can specify the tweet's URL or status ID using the "--url" flag. If the "--undo" flag is provided,
+it will undo the action, i.e., unpin the tweet. The profile is checked afterwards to confirm the change.
+
+Examples:
+- Pin a tweet:
+  tweethub pin --url <tweet-url>
+
+- Unpin a tweet:
+  tweethub pin --url <tweet-url> --undo`,
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		ref, err := tweethub.ParseTweetRef(url)
+		cobra.CheckErr(err)
+		tweetURL := ref.URL()
+
+		switch {
+		case undo:
+			cancel := tweetHub.UnPin(tweetURL)
+			defer cancel()
+		default:
+			cancel := tweetHub.Pin(tweetURL)
+			defer cancel()
+		}
+	},
+}
+
+func init() {
+	pinCmd.Flags().StringVar(&url, "url", "", "Specify the URL or status ID of the tweet.")
+	pinCmd.Flags().BoolVar(&undo, "undo", false, "Undo the pin action (unpin).")
+
+	pinCmd.MarkFlagRequired("url")
+
+	rootCmd.AddCommand(pinCmd)
+}
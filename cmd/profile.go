@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileName     string
+	profileBio      string
+	profileLocation string
+	profileWebsite  string
+	profileAvatar   string
+	profileBanner   string
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Read or edit the profile of the account.",
+	Long: `The profile command allows you to read the profile of the account as JSON
+or change it through the edit profile dialog.
+
+Examples:
+- Print the current profile:
+  tweethub profile get
+
+- Change the bio and the avatar:
+  tweethub profile set --bio "Official account" --avatar avatar.png`,
+}
+
+// profileGetCmd represents the profile get command
+var profileGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Print the profile of the account as JSON.",
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		if plannedRead("profile") {
+			return
+		}
+
+		profile, cancel, err := tweetHub.Profile()
+		defer cancel()
+		cobra.CheckErr(err)
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		cobra.CheckErr(enc.Encode(profile))
+	},
+}
+
+// profileSetCmd represents the profile set command
+var profileSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change the profile of the account.",
+	Long: `The set command changes the given fields of the profile and leaves the others untouched.
+Pass an empty value, e.g. --bio "", to clear a field.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		update := tweethub.ProfileUpdate{
+			Avatar: profileAvatar,
+			Banner: profileBanner,
+		}
+
+		fields := []struct {
+			flag  string
+			value *string
+			field **string
+		}{
+			{"name", &profileName, &update.Name},
+			{"bio", &profileBio, &update.Bio},
+			{"location", &profileLocation, &update.Location},
+			{"website", &profileWebsite, &update.Website},
+		}
+
+		changed := update.Avatar != "" || update.Banner != ""
+		for _, f := range fields {
+			if cmd.Flags().Changed(f.flag) {
+				*f.field = f.value
+				changed = true
+			}
+		}
+
+		if !changed {
+			cobra.CheckErr(fmt.Errorf("nothing to change, provide at least one field"))
+		}
+
+		cobra.CheckErr(update.Validate())
+
+		cancel := tweetHub.UpdateProfile(update)
+		defer cancel()
+	},
+}
+
+func init() {
+	profileSetCmd.Flags().StringVar(&profileName, "name", "", "Display name.")
+	profileSetCmd.Flags().StringVar(&profileBio, "bio", "", "Bio.")
+	profileSetCmd.Flags().StringVar(&profileLocation, "location", "", "Location.")
+	profileSetCmd.Flags().StringVar(&profileWebsite, "website", "", "Website.")
+	profileSetCmd.Flags().StringVar(&profileAvatar, "avatar", "", "Image file for the profile picture.")
+	profileSetCmd.Flags().StringVar(&profileBanner, "banner", "", "Image file for the header banner.")
+
+	profileCmd.AddCommand(profileGetCmd)
+	profileCmd.AddCommand(profileSetCmd)
+
+	rootCmd.AddCommand(profileCmd)
+}
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"math/rand"
-
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // quoteCmd represents the quote command
@@ -13,44 +10,34 @@ var quoteCmd = &cobra.Command{
 	Short: "Quote a tweet with a custom message.",
 	Long: `The quote command allows you to quote a tweet on Twitter with a custom message.
 You can specify the tweet's URL using the "--url" flag and provide a custom message
-using the "--message" flag.
+using the "--message" flag, "--message-file" or "--edit". Media can be attached with "--media" and described with "--alt".
+The "--reply-settings", "--sensitive" and "--schedule-at" flags work as in the tweet command.
 
 Examples:
 - Quote a tweet with a custom message:
-  tweethub quote --url <tweet-url> --message "Your custom message here"`,
+  tweethub quote --url <tweet-url> --message "Your custom message here"
+
+- Quote a tweet with an image:
+  tweethub quote --url <tweet-url> --message "Look at this" --media chart.png --alt "Weekly chart"`,
 	Run: func(cmd *cobra.Command, args []string) {
-		messages := viper.GetStringSlice("messages")
-		messagesLenght := len(messages)
+		readMessage()
+		setCompose()
 
 		switch {
-		case allAccounts:
-			for _, user := range accounts {
+		case len(selected) > 1:
+			for _, user := range selected {
 				tweetHub.SetUsername(user.Username)
 				tweetHub.SetPassword(user.Password)
 
 				if useMessages {
-					message = viper.GetString("messages.0")
-
-					if random {
-						if messagesLenght > 0 {
-							idx := rand.Int63n(int64(messagesLenght))
-							message = messages[idx]
-						}
-					}
+					message = pickMessage(user.Username, url)
 				}
 
 				cancel := tweetHub.Quote(url, message)
 				cancel()
 			}
 		case useMessages:
-			message = viper.GetString("messages.0")
-
-			if random {
-				if messagesLenght > 0 {
-					idx := rand.Int63n(int64(messagesLenght))
-					message = messages[idx]
-				}
-			}
+			message = pickMessage(selected[0].Username, url)
 
 			cancel := tweetHub.Quote(url, message)
 			defer cancel()
@@ -70,5 +57,7 @@ func init() {
 
 	quoteCmd.MarkFlagRequired("url")
 
+	addComposeFlags(quoteCmd)
+
 	rootCmd.AddCommand(quoteCmd)
 }
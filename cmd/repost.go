@@ -21,8 +21,8 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		switch {
 		case undo:
-			if allAccounts {
-				for _, user := range accounts {
+			if len(selected) > 1 {
+				for _, user := range selected {
 					tweetHub.SetUsername(user.Username)
 					tweetHub.SetPassword(user.Password)
 
@@ -34,8 +34,8 @@ Examples:
 			cancel := tweetHub.UnRepost(url)
 			defer cancel()
 		default:
-			if allAccounts {
-				for _, user := range accounts {
+			if len(selected) > 1 {
+				for _, user := range selected {
 					tweetHub.SetUsername(user.Username)
 					tweetHub.SetPassword(user.Password)
 
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var (
+	retentionLog string
+	daemon       bool
+	interval     time.Duration
+)
+
+// minInterval is the shortest time between runs in daemon mode.
+const minInterval = time.Minute
+
+// RetentionPolicy decides which tweets of an account are deleted by the
+// retention command. It is read from the "retention" key of the account.
+type RetentionPolicy struct {
+	OlderThan      int      `mapstructure:"older_than" json:"older_than"`
+	KeepPinned     bool     `mapstructure:"keep_pinned" json:"keep_pinned"`
+	KeepHashtags   []string `mapstructure:"keep_hashtags" json:"keep_hashtags,omitempty"`
+	KeepAboveLikes int      `mapstructure:"keep_above_likes" json:"keep_above_likes"`
+
+	// keep holds the compiled KeepHashtags, set by compile.
+	keep []*regexp.Regexp
+}
+
+// retentionEntry is a line of the retention audit log.
+type retentionEntry struct {
+	Time      time.Time `json:"time"`
+	Account   string    `json:"account"`
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	Likes     int       `json:"likes"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// retentionCmd represents the retention command
+var retentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Apply the retention policies of the accounts.",
+	Long: `The retention command deletes the tweets of each account that its retention policy
+no longer allows to keep. Policies are set per account in the configuration file:
+
+  accounts:
+    - username: <username>
+      password: <password>
+      retention:
+        older_than: 90           # delete tweets older than 90 days...
+        keep_pinned: true        # ...except the pinned tweet,
+        keep_hashtags: ["#keep"] # those with one of these hashtags
+        keep_above_likes: 100    # and those with more than 100 likes.
+
+Accounts without a policy, or with "older_than" unset, are left alone.`,
+}
+
+// retentionRunCmd represents the retention run command
+var retentionRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Delete the tweets the retention policies do not keep.",
+	Long: `The run command applies the retention policy of every account that has one, or of
+the account given with "--account". Every deletion, successful or not, is appended
+as a JSON line to the audit log. An account that fails is reported and the others
+are still processed. With "--daemon" the policies are applied again after each
+"--interval", of at least one minute, until the command is stopped.
+
+Examples:
+- Preview what the policies would delete:
+  tweethub retention run --dry-run
+
+- Apply the policies every six hours:
+  tweethub retention run --daemon --interval 6h --log /var/log/tweethub-retention.jsonl`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if daemon && interval < minInterval {
+			cobra.CheckErr(fmt.Errorf("--interval must be at least %s in daemon mode", minInterval))
+		}
+
+		users := accounts
+		if len(accountNames) > 0 {
+			users = selected
+		}
+
+		if retentionLog == "" {
+			retentionLog = filepath.Join(configDir(), "retention.jsonl")
+		}
+
+		for {
+			failed := 0
+
+			for _, user := range users {
+				if user.Retention == nil || user.Retention.OlderThan <= 0 {
+					continue
+				}
+
+				if err := applyRetention(user); err != nil {
+					fmt.Fprintf(os.Stderr, "@%s: %v\n", user.Username, err)
+					failed++
+				}
+			}
+
+			if !daemon {
+				if failed > 0 {
+					cobra.CheckErr(fmt.Errorf("the retention policy of %d accounts could not be applied", failed))
+				}
+				return
+			}
+
+			fmt.Fprintf(os.Stderr, "Next run at %s\n", time.Now().Add(interval).Format(time.RFC3339))
+			time.Sleep(interval)
+		}
+	},
+}
+
+// applyRetention deletes the tweets of user its retention policy does not
+// keep, logging each deletion to the audit log.
+func applyRetention(user Account) error {
+	policy := *user.Retention
+	if err := policy.compile(); err != nil {
+		return err
+	}
+
+	useAccount(user)
+
+	s, cancel, err := tweetHub.NewSession()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	var expired []tweethub.Post
+
+	now := time.Now()
+
+	err = s.Timeline(user.Username, func(post tweethub.Post) bool {
+		if policy.expired(post, now) {
+			expired = append(expired, post)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		for _, post := range expired {
+			fmt.Printf("%s\t%s\n", post.URL, oneLine(post.Text))
+		}
+		fmt.Fprintf(os.Stderr, "@%s: %d tweets would be deleted\n", user.Username, len(expired))
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(retentionLog), 0o700); err != nil {
+		return err
+	}
+
+	log, err := os.OpenFile(retentionLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	enc := json.NewEncoder(log)
+	failed := 0
+
+	for _, post := range expired {
+		entry := retentionEntry{
+			Account:   user.Username,
+			ID:        post.ID,
+			URL:       post.URL,
+			Text:      post.Text,
+			CreatedAt: post.CreatedAt,
+			Likes:     post.Likes,
+			Result:    "deleted",
+		}
+
+		if err := s.UnTweet(post.URL); err != nil {
+			entry.Result, entry.Error = "failed", err.Error()
+			failed++
+		}
+
+		entry.Time = time.Now().UTC()
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: %s\n", post.URL, entry.Result)
+	}
+
+	fmt.Printf("@%s: %d deleted, %d failed\n", user.Username, len(expired)-failed, failed)
+
+	return nil
+}
+
+// compile compiles the KeepHashtags patterns used by expired.
+func (p *RetentionPolicy) compile() error {
+	p.keep = nil
+
+	for _, hashtag := range p.KeepHashtags {
+		pattern := `(?i)#` + regexp.QuoteMeta(strings.TrimPrefix(hashtag, "#")) + `([^\p{L}\p{N}_]|$)`
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("keep_hashtags %q: %v", hashtag, err)
+		}
+		p.keep = append(p.keep, re)
+	}
+
+	return nil
+}
+
+// expired reports whether post is due for deletion under the policy at now.
+// Posts without a date are never due. The policy must have been compiled.
+func (p RetentionPolicy) expired(post tweethub.Post, now time.Time) bool {
+	if p.OlderThan <= 0 || post.CreatedAt.IsZero() || !post.CreatedAt.Before(now.AddDate(0, 0, -p.OlderThan)) {
+		return false
+	}
+
+	if p.KeepPinned && post.Pinned {
+		return false
+	}
+
+	if p.KeepAboveLikes > 0 && post.Likes > p.KeepAboveLikes {
+		return false
+	}
+
+	for _, re := range p.keep {
+		if re.MatchString(post.Text) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func init() {
+	retentionRunCmd.Flags().BoolVar(&daemon, "daemon", false, "Keep running and apply the policies periodically.")
+	retentionRunCmd.Flags().DurationVar(&interval, "interval", 24*time.Hour, "Time between runs in daemon mode.")
+	retentionRunCmd.Flags().StringVar(&retentionLog, "log", "", "Audit log file (default is retention.jsonl next to the config file).")
+
+	retentionCmd.AddCommand(retentionRunCmd)
+
+	rootCmd.AddCommand(retentionCmd)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+)
+
+func TestRetentionPolicyExpired(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -100)
+	recent := now.AddDate(0, 0, -10)
+
+	policy := RetentionPolicy{
+		OlderThan:      90,
+		KeepPinned:     true,
+		KeepHashtags:   []string{"#keep", "Archive"},
+		KeepAboveLikes: 100,
+	}
+
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		post   tweethub.Post
+		want   bool
+	}{
+		{name: "old", policy: policy, post: tweethub.Post{Text: "hello", CreatedAt: old}, want: true},
+		{name: "recent", policy: policy, post: tweethub.Post{Text: "hello", CreatedAt: recent}},
+		{name: "undated", policy: policy, post: tweethub.Post{Text: "hello"}},
+		{name: "no policy", policy: RetentionPolicy{}, post: tweethub.Post{Text: "hello", CreatedAt: old}},
+		{name: "pinned", policy: policy, post: tweethub.Post{Text: "hello", CreatedAt: old, Pinned: true}},
+		{name: "pinned without keep_pinned", policy: RetentionPolicy{OlderThan: 90}, post: tweethub.Post{Text: "hello", CreatedAt: old, Pinned: true}, want: true},
+		{name: "above likes", policy: policy, post: tweethub.Post{Text: "hello", CreatedAt: old, Likes: 101}},
+		{name: "at likes", policy: policy, post: tweethub.Post{Text: "hello", CreatedAt: old, Likes: 100}, want: true},
+		{name: "hashtag", policy: policy, post: tweethub.Post{Text: "hello #keep", CreatedAt: old}},
+		{name: "hashtag any case", policy: policy, post: tweethub.Post{Text: "#KEEP, hello", CreatedAt: old}},
+		{name: "hashtag without #", policy: policy, post: tweethub.Post{Text: "hello #archive", CreatedAt: old}},
+		{name: "longer hashtag", policy: policy, post: tweethub.Post{Text: "hello #keeper", CreatedAt: old}, want: true},
+		{name: "word without #", policy: policy, post: tweethub.Post{Text: "keep this", CreatedAt: old}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.policy
+			if err := p.compile(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := p.expired(tt.post, now); got != tt.want {
+				t.Errorf("expired() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
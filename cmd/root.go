@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/alomia/tweethub-cli/internal/tweethub"
 	"github.com/spf13/cobra"
@@ -11,8 +14,13 @@ import (
 )
 
 type Account struct {
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
+	Username  string           `mapstructure:"username"`
+	Password  string           `mapstructure:"password"`
+	Alias     string           `mapstructure:"alias"`
+	Label     string           `mapstructure:"label"`
+	Timezone  string           `mapstructure:"timezone"`
+	Tags      []string         `mapstructure:"tags"`
+	Retention *RetentionPolicy `mapstructure:"retention"`
 }
 
 var (
@@ -24,9 +32,28 @@ var (
 	random      bool
 	allAccounts bool
 	useMessages bool
+	messageFile string
+	edit        bool
+
+	mediaPaths    []string
+	altTexts      []string
+	pollOptions   []string
+	pollDuration  string
+	replySettings string
+	sensitive     bool
+	scheduleAt    string
+
+	dryRun bool
+
+	accountNames []string
+	selected     []Account
 
 	accounts []Account
 	tweetHub *tweethub.TweetHub
+
+	// configErr is the error reading the configuration file, reported by the
+	// commands that need one.
+	configErr error
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -42,6 +69,7 @@ Examples:
   - Tweet a message: tweethub-cli tweet -m "Hello, world!"
   - Delete a tweet: tweethub-cli tweet --undo --url <tweet-url>
   - Use predefined messages: tweethub-cli tweet --use-messages
+  - Act as another account: tweethub-cli like --url <tweet-url> --account <username|alias>
 
 Explore the full range of features by checking the available commands and their respective options.
 
@@ -50,6 +78,24 @@ This application aims to simplify the interaction with Twitter through the comma
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if configErr != nil && !optional(cmd, "config") {
+			cobra.CheckErr(configErr)
+		}
+
+		if len(accounts) == 0 {
+			if !optional(cmd, "accounts") {
+				cobra.CheckErr(errNoAccounts())
+			}
+			return
+		}
+
+		var err error
+		selected, err = selectAccounts()
+		cobra.CheckErr(err)
+
+		useAccount(selected[0])
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -59,6 +105,11 @@ func Execute() {
 	if err != nil {
 		os.Exit(1)
 	}
+
+	if tweetHub != nil && tweetHub.InvalidPlans() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of the planned actions are invalid\n", tweetHub.InvalidPlans())
+		os.Exit(1)
+	}
 }
 
 func init() {
@@ -69,11 +120,129 @@ func init() {
 		}
 	}, func() {
 		tweetHub = tweethub.New()
-		tweetHub.SetUsername(accounts[0].Username)
-		tweetHub.SetPassword(accounts[0].Password)
+		tweetHub.SetRecorder(recordTweetHubAction)
+		tweetHub.SetDryRun(dryRun)
 	})
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ./tweethub.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print what would be done for each account without doing it")
+	rootCmd.PersistentFlags().StringArrayVar(&accountNames, "account", nil, "Username or alias of the account to use (repeatable, default is the first account)")
+}
+
+// optionalAccounts marks commands that can run without configured accounts.
+var optionalAccounts = map[string]string{"accounts": "optional"}
+
+// optionalConfig marks commands that can run without a configuration file,
+// and then without accounts.
+var optionalConfig = map[string]string{"accounts": "optional", "config": "optional"}
+
+// optional reports whether cmd, or one of its parents, can run without the
+// given requirement, "accounts" or "config".
+func optional(cmd *cobra.Command, requirement string) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[requirement] == "optional" {
+			return true
+		}
+		if c.Name() == "help" || c.Name() == "completion" || c.Name() == cobra.ShellCompRequestCmd {
+			return true
+		}
+	}
+
+	return false
+}
+
+// errNoAccounts explains that the configuration file has no accounts.
+func errNoAccounts() error {
+	return fmt.Errorf("no accounts configured in %s, add at least one under \"accounts:\" with a username and password", viper.ConfigFileUsed())
+}
+
+// selectAccounts returns the accounts given with --account, every account
+// with --all-accounts, or the first one.
+func selectAccounts() ([]Account, error) {
+	if len(accounts) == 0 {
+		return nil, errNoAccounts()
+	}
+
+	if len(accountNames) == 0 {
+		if allAccounts {
+			return accounts, nil
+		}
+		return accounts[:1], nil
+	}
+
+	if allAccounts {
+		return nil, fmt.Errorf("--account and --all-accounts cannot be used together")
+	}
+
+	var users []Account
+	seen := make(map[string]bool)
+
+	for _, name := range accountNames {
+		user, err := lookupAccount(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[strings.ToLower(user.Username)] {
+			seen[strings.ToLower(user.Username)] = true
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+// singleAccount returns the selected account of commands that work with
+// one account at a time.
+func singleAccount() Account {
+	if len(selected) == 0 {
+		cobra.CheckErr(errNoAccounts())
+	}
+	if len(selected) > 1 {
+		cobra.CheckErr(fmt.Errorf("this command works with one account, got %d", len(selected)))
+	}
+
+	return selected[0]
+}
+
+// lookupAccount returns the configured account with the given username or alias.
+func lookupAccount(name string) (Account, error) {
+	name = strings.TrimPrefix(name, "@")
+
+	for _, user := range accounts {
+		if strings.EqualFold(user.Username, name) || (user.Alias != "" && strings.EqualFold(user.Alias, name)) {
+			return user, nil
+		}
+	}
+
+	return Account{}, fmt.Errorf("account %q not found in the configuration file", name)
+}
+
+// findAccount returns the configured account with the given username or alias.
+func findAccount(name string) Account {
+	user, err := lookupAccount(name)
+	cobra.CheckErr(err)
+
+	return user
+}
+
+// useAccount makes tweetHub act as user.
+func useAccount(user Account) {
+	tweetHub.SetUsername(user.Username)
+	tweetHub.SetPassword(user.Password)
+}
+
+// plannedRead prints, in dry-run mode, what a command that only reads the
+// account would read, and reports whether the command must stop there
+// instead of opening the browser.
+func plannedRead(what string) bool {
+	if !dryRun {
+		return false
+	}
+
+	fmt.Printf("[dry-run] @%s: read %s\n", tweetHub.Username(), what)
+
+	return true
 }
 
 func initConfig() {
@@ -81,6 +250,9 @@ func initConfig() {
 	cobra.CheckErr(err)
 
 	fullPath := filepath.Join(baseDir, cfgFile)
+	if cfgFile == "" {
+		fullPath = filepath.Join(baseDir, "tweethub.yaml")
+	}
 
 	if cfgFile != "" {
 		// Use config file from the flag.
@@ -92,8 +264,22 @@ func initConfig() {
 	}
 
 	if err := viper.ReadInConfig(); err != nil {
-		cobra.CheckErr(fmt.Errorf("Error reading config file: %v", err))
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) && !errors.Is(err, fs.ErrNotExist) {
+			cobra.CheckErr(fmt.Errorf("Error reading config file: %v", err))
+		}
+
+		// The accounts commands create the file, so they can run without it.
+		configErr = fmt.Errorf("Error reading config file: %v", err)
+		viper.SetConfigFile(fullPath)
+		return
 	}
 
-	fmt.Println("Using config file:", viper.ConfigFileUsed())
+	fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+}
+
+// configDir returns the directory of the configuration file in use, where
+// tweethub keeps its local data.
+func configDir() string {
+	return filepath.Dir(viper.ConfigFileUsed())
 }
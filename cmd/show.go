@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+	"github.com/spf13/cobra"
+)
+
+var showFormat string
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a tweet as JSON or text.",
+	Long: `The show command reads a tweet from its page and prints its author, text, creation time,
+media, reply, repost, like and view counts, the quoted tweet and the tweet it replies to in the thread.
+You can specify the tweet's URL or status ID using the "--url" flag.
+
+Examples:
+- Show a tweet as JSON:
+  tweethub show --url <tweet-url>
+
+- Show a tweet as text:
+  tweethub show --url <tweet-url> --format text`,
+	Run: func(cmd *cobra.Command, args []string) {
+		useAccount(singleAccount())
+
+		if showFormat != "json" && showFormat != "text" {
+			cobra.CheckErr(fmt.Errorf("invalid format %q, expected json or text", showFormat))
+		}
+
+		if dryRun {
+			_, err := tweethub.ParseTweetRef(url)
+			cobra.CheckErr(err)
+		}
+		if plannedRead("tweet " + url) {
+			return
+		}
+
+		post, cancel, err := tweetHub.Show(url)
+		defer cancel()
+		cobra.CheckErr(err)
+
+		if showFormat == "text" {
+			printPost(post)
+			return
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		cobra.CheckErr(enc.Encode(post))
+	},
+}
+
+// printPost prints post in a human readable form.
+func printPost(post tweethub.Post) {
+	if post.Parent != nil {
+		fmt.Printf("In reply to @%s: %s\n\n", post.Parent.Author, oneLine(post.Parent.Text))
+	}
+
+	fmt.Printf("@%s · %s\n", post.Author, post.CreatedAt.Local().Format("2006-01-02 15:04"))
+	fmt.Println(post.Text)
+
+	if post.Quoted != nil {
+		fmt.Printf("\nQuoting @%s: %s\n", post.Quoted.Author, oneLine(post.Quoted.Text))
+		if post.Quoted.URL != "" {
+			fmt.Println(post.Quoted.URL)
+		}
+	}
+
+	for _, media := range post.Media {
+		fmt.Println("Media:", media)
+	}
+
+	fmt.Printf("\n%d replies · %d reposts · %d likes · %d views\n", post.Replies, post.Reposts, post.Likes, post.Views)
+	fmt.Println(post.URL)
+}
+
+// oneLine joins the lines of text with spaces.
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func init() {
+	showCmd.Flags().StringVar(&url, "url", "", "Specify the URL or status ID of the tweet.")
+	showCmd.Flags().StringVar(&showFormat, "format", "json", "Output format: json or text.")
+
+	showCmd.MarkFlagRequired("url")
+
+	rootCmd.AddCommand(showCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alomia/tweethub-cli/internal/store"
+)
+
+// historyStore is the store opened by openStore.
+var historyStore *store.Store
+
+// openStore opens the history store next to the configuration file, once.
+func openStore() (*store.Store, error) {
+	if historyStore != nil {
+		return historyStore, nil
+	}
+
+	s, err := store.Open(filepath.Join(configDir(), "tweethub-store"))
+	if err != nil {
+		return nil, err
+	}
+
+	historyStore = s
+
+	return s, nil
+}
+
+// record saves a record with fn in the history store. Failures are reported
+// as warnings, since the action itself already happened.
+func record(fn func(*store.Store) error) {
+	s, err := openStore()
+	if err == nil {
+		err = fn(s)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not record to the history store: %v\n", err)
+	}
+}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
-	"math/rand"
+	"context"
+	"time"
 
+	"github.com/alomia/tweethub-cli/internal/store"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // tweetCmd represents the tweet command
@@ -13,59 +15,78 @@ var tweetCmd = &cobra.Command{
 	Short: "Send a tweet on Twitter",
 	Long: `The "tweet" command allows you to post tweets on Twitter.
 
-You can specify the content of the tweet using the "--message" flag. If you want to use predefined messages from the configuration file, provide the "--use-messages" flag. Additionally, you can choose to send a random message using the "--random" flag.
+You can specify the content of the tweet using the "--message" flag, read it from a file with "--message-file" ("-" reads standard input) or write it in $EDITOR with "--edit". If you want to use predefined messages from the configuration file, provide the "--use-messages" flag. Additionally, you can choose to send a random message using the "--random" flag. Predefined messages are templates, see "tweethub-cli messages --help".
+
+A poll can be added with two to four "--poll-option" flags and a "--poll-duration" such as "1d", "6h" or "1d12h" (between 5 minutes and 7 days).
+
+Images, GIFs and videos can be attached with the repeatable "--media" flag. Each "--alt" flag sets the alt text of the media file in the same position, and "--sensitive" flags the media as sensitive content.
+
+Use "--reply-settings" to choose who can reply (everyone, following or mentioned) and "--schedule-at" to schedule the tweet with the composer instead of posting it immediately.
 
 Examples:
   tweethub-cli tweet --message "Hello, world!"
   tweethub-cli tweet --use-messages
+  tweethub-cli tweet --message-file announcement.txt
+  git log -1 --format=%B | tweethub-cli tweet --message-file -
+  tweethub-cli tweet --edit
+  tweethub-cli tweet --message "Launch day!" --media banner.png --alt "Product banner"
+  tweethub-cli tweet --message "Tabs or spaces?" --poll-option Tabs --poll-option Spaces --poll-duration 1d
+  tweethub-cli tweet --message "See you tomorrow" --reply-settings following --schedule-at "2024-01-15 09:30"
   tweethub-cli tweet --undo --url <tweet-url>`,
 	Run: func(cmd *cobra.Command, args []string) {
-		messages := viper.GetStringSlice("messages")
-		messagesLenght := len(messages)
+		readMessage()
+		setCompose()
+		setPoll()
 
 		switch {
 		case undo:
 			cancel := tweetHub.UnTweet(url)
 			defer cancel()
 
-		case allAccounts:
-			for _, user := range accounts {
+		case len(selected) > 1:
+			for _, user := range selected {
 				tweetHub.SetUsername(user.Username)
 				tweetHub.SetPassword(user.Password)
 
 				if useMessages {
-					message = viper.GetString("messages.0")
-
-					if random {
-						if messagesLenght > 0 {
-							idx := rand.Int63n(int64(messagesLenght))
-							message = messages[idx]
-						}
-					}
+					message = pickMessage(user.Username, "")
 				}
 
-				cancel := tweetHub.Tweet(message)
+				cancel := postTweet(message)
 				cancel()
 			}
 		case useMessages:
-			message = viper.GetString("messages.0")
-
-			if random {
-				if messagesLenght > 0 {
-					idx := rand.Int63n(int64(messagesLenght))
-					message = messages[idx]
-				}
-			}
+			message = pickMessage(selected[0].Username, "")
 
-			cancel := tweetHub.Tweet(message)
+			cancel := postTweet(message)
 			defer cancel()
 		default:
-			cancel := tweetHub.Tweet(message)
+			cancel := postTweet(message)
 			defer cancel()
 		}
 	},
 }
 
+// postTweet tweets message as the current account and records the created
+// tweet in the history store.
+func postTweet(message string) context.CancelFunc {
+	tweetURL, cancel := tweetHub.Tweet(message)
+
+	if ref, err := tweethub.ParseTweetRef(tweetURL); err == nil {
+		record(func(s *store.Store) error {
+			return s.RecordPost(store.Post{
+				ID:        ref.ID,
+				Account:   tweetHub.Username(),
+				URL:       tweetURL,
+				Text:      message,
+				CreatedAt: time.Now().UTC(),
+			})
+		})
+	}
+
+	return cancel
+}
+
 func init() {
 	tweetCmd.Flags().StringVarP(&message, "message", "m", "", "Specify the content of the tweet.")
 	tweetCmd.Flags().StringVar(&url, "url", "", "Specify the URL of the tweet to be deleted.")
@@ -74,5 +95,10 @@ func init() {
 	tweetCmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "Use all accounts")
 	tweetCmd.Flags().BoolVar(&useMessages, "use-messages", false, "Use predefined messages from the configuration file")
 
+	tweetCmd.Flags().StringArrayVar(&pollOptions, "poll-option", nil, "Add a poll option (repeatable, 2 to 4 options).")
+	tweetCmd.Flags().StringVar(&pollDuration, "poll-duration", "1d", "Duration of the poll, e.g. 30m, 6h, 1d12h.")
+
+	addComposeFlags(tweetCmd)
+
 	rootCmd.AddCommand(tweetCmd)
 }
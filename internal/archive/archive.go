@@ -0,0 +1,308 @@
+// Package archive reads the data archive Twitter lets account owners download
+// and keeps the parts tweethub needs in a local file for offline queries.
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+)
+
+// createdAtLayout is the layout of the created_at field of archived tweets.
+const createdAtLayout = "Mon Jan 02 15:04:05 -0700 2006"
+
+var partPattern = regexp.MustCompile(`^data/(tweets?|like|follower|following|account)(?:-part\d+)?\.js$`)
+
+// Tweet is a tweet of the archive.
+type Tweet struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	InReplyTo string    `json:"in_reply_to,omitempty"`
+	Retweet   bool      `json:"retweet,omitempty"`
+	Media     []string  `json:"media,omitempty"`
+	Likes     int       `json:"likes"`
+	Retweets  int       `json:"retweets"`
+}
+
+// Like is a tweet liked by the account.
+type Like struct {
+	ID   string `json:"id"`
+	Text string `json:"text,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Archive holds the imported parts of a Twitter data archive.
+type Archive struct {
+	Account    string    `json:"account"`
+	AccountID  string    `json:"account_id"`
+	ImportedAt time.Time `json:"imported_at"`
+	Tweets     []Tweet   `json:"tweets"`
+	Likes      []Like    `json:"likes"`
+	Followers  []string  `json:"followers"`
+	Following  []string  `json:"following"`
+}
+
+// Filter selects tweets of the archive. Zero fields match every tweet. Since
+// and Until are inclusive.
+type Filter struct {
+	Since           time.Time
+	Until           time.Time
+	Match           *regexp.Regexp
+	HasMedia        bool
+	Replies         bool
+	Retweets        bool
+	ExcludeReplies  bool
+	ExcludeRetweets bool
+}
+
+// Import reads the archive zip file at zipPath.
+func Import(zipPath string) (*Archive, error) {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	a := &Archive{ImportedAt: time.Now().UTC()}
+
+	for _, f := range r.File {
+		m := partPattern.FindStringSubmatch(path.Clean(f.Name))
+		if m == nil {
+			continue
+		}
+
+		data, err := readFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %v", f.Name, err)
+		}
+
+		if err := a.parse(m[1], data); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", f.Name, err)
+		}
+	}
+
+	if a.Account == "" {
+		return nil, fmt.Errorf("%s is not a Twitter archive: data/account.js not found", zipPath)
+	}
+
+	sort.Slice(a.Tweets, func(i, j int) bool {
+		return a.Tweets[i].CreatedAt.After(a.Tweets[j].CreatedAt)
+	})
+
+	return a, nil
+}
+
+// readFile returns the content of f with the "window.YTD.<name>.part0 = "
+// assignment stripped, leaving the JSON array.
+func readFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	i := bytes.IndexByte(data, '=')
+	if i < 0 {
+		return nil, fmt.Errorf("missing window.YTD assignment")
+	}
+
+	return data[i+1:], nil
+}
+
+// parse adds the entries of an archive part of the given kind to a.
+func (a *Archive) parse(kind string, data []byte) error {
+	switch kind {
+	case "account":
+		var entries []struct {
+			Account struct {
+				Username  string `json:"username"`
+				AccountID string `json:"accountId"`
+			} `json:"account"`
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			a.Account = entries[0].Account.Username
+			a.AccountID = entries[0].Account.AccountID
+		}
+
+	case "tweet", "tweets":
+		var entries []struct {
+			Tweet archivedTweet `json:"tweet"`
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			tweet, err := entry.Tweet.convert()
+			if err != nil {
+				return err
+			}
+			a.Tweets = append(a.Tweets, tweet)
+		}
+
+	case "like":
+		var entries []struct {
+			Like struct {
+				TweetID     string `json:"tweetId"`
+				FullText    string `json:"fullText"`
+				ExpandedURL string `json:"expandedUrl"`
+			} `json:"like"`
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			a.Likes = append(a.Likes, Like{ID: entry.Like.TweetID, Text: entry.Like.FullText, URL: entry.Like.ExpandedURL})
+		}
+
+	case "follower", "following":
+		var entries []map[string]struct {
+			AccountID string `json:"accountId"`
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			for _, account := range entry {
+				if kind == "follower" {
+					a.Followers = append(a.Followers, account.AccountID)
+				} else {
+					a.Following = append(a.Following, account.AccountID)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// archivedTweet is a tweet as stored in data/tweets.js.
+type archivedTweet struct {
+	ID            string `json:"id_str"`
+	FullText      string `json:"full_text"`
+	CreatedAt     string `json:"created_at"`
+	InReplyTo     string `json:"in_reply_to_status_id_str"`
+	FavoriteCount string `json:"favorite_count"`
+	RetweetCount  string `json:"retweet_count"`
+	Entities      struct {
+		Media []archivedMedia `json:"media"`
+	} `json:"entities"`
+	ExtendedEntities struct {
+		Media []archivedMedia `json:"media"`
+	} `json:"extended_entities"`
+}
+
+type archivedMedia struct {
+	MediaURL string `json:"media_url_https"`
+}
+
+// convert turns an archived tweet into a Tweet.
+func (t archivedTweet) convert() (Tweet, error) {
+	createdAt, err := time.Parse(createdAtLayout, t.CreatedAt)
+	if err != nil {
+		return Tweet{}, fmt.Errorf("tweet %s: %v", t.ID, err)
+	}
+
+	tweet := Tweet{
+		ID:        t.ID,
+		Text:      t.FullText,
+		CreatedAt: createdAt.UTC(),
+		InReplyTo: t.InReplyTo,
+		Retweet:   strings.HasPrefix(t.FullText, "RT @"),
+	}
+
+	tweet.Likes, _ = strconv.Atoi(t.FavoriteCount)
+	tweet.Retweets, _ = strconv.Atoi(t.RetweetCount)
+
+	media := t.ExtendedEntities.Media
+	if len(media) == 0 {
+		media = t.Entities.Media
+	}
+	for _, m := range media {
+		tweet.Media = append(tweet.Media, m.MediaURL)
+	}
+
+	return tweet, nil
+}
+
+// Load reads an archive saved with Save.
+func Load(path string) (*Archive, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var a Archive
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	return &a, nil
+}
+
+// Save writes the archive to path as JSON.
+func (a *Archive) Save(path string) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Query returns the tweets of the archive matching f, newest first.
+func (a *Archive) Query(f Filter) []Tweet {
+	var tweets []Tweet
+
+	for _, tweet := range a.Tweets {
+		if f.matches(tweet) {
+			tweets = append(tweets, tweet)
+		}
+	}
+
+	return tweets
+}
+
+// matches reports whether tweet passes every condition of f.
+func (f Filter) matches(tweet Tweet) bool {
+	reply := tweet.InReplyTo != ""
+
+	switch {
+	case !f.Since.IsZero() && tweet.CreatedAt.Before(f.Since):
+	case !f.Until.IsZero() && tweet.CreatedAt.After(f.Until):
+	case f.Match != nil && !f.Match.MatchString(tweet.Text):
+	case f.HasMedia && len(tweet.Media) == 0:
+	case f.Replies && !reply:
+	case f.Retweets && !tweet.Retweet:
+	case f.ExcludeReplies && reply:
+	case f.ExcludeRetweets && tweet.Retweet:
+	default:
+		return true
+	}
+
+	return false
+}
+
+// URL returns the URL of the tweet posted by username.
+func (t Tweet) URL(username string) string {
+	return tweethub.TweetRef{ID: t.ID, Username: username}.URL()
+}
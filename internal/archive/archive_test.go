@@ -0,0 +1,59 @@
+package archive
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	a := &Archive{Tweets: []Tweet{
+		{ID: "5", Text: "Launch day #launch", CreatedAt: day(5), Media: []string{"photo.jpg"}},
+		{ID: "4", Text: "RT @bob: hello", CreatedAt: day(4), Retweet: true},
+		{ID: "3", Text: "@bob thanks!", CreatedAt: day(3), InReplyTo: "2"},
+		{ID: "2", Text: "Hello world", CreatedAt: day(2)},
+		{ID: "1", Text: "First tweet", CreatedAt: day(1)},
+	}}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{name: "all", want: []string{"5", "4", "3", "2", "1"}},
+		{name: "since", filter: Filter{Since: day(4)}, want: []string{"5", "4"}},
+		{name: "until is inclusive", filter: Filter{Until: day(2)}, want: []string{"2", "1"}},
+		{name: "range", filter: Filter{Since: day(2), Until: day(4)}, want: []string{"4", "3", "2"}},
+		{name: "empty range", filter: Filter{Since: day(4), Until: day(2)}},
+		{name: "match", filter: Filter{Match: regexp.MustCompile(`(?i)hello`)}, want: []string{"4", "2"}},
+		{name: "has media", filter: Filter{HasMedia: true}, want: []string{"5"}},
+		{name: "replies", filter: Filter{Replies: true}, want: []string{"3"}},
+		{name: "retweets", filter: Filter{Retweets: true}, want: []string{"4"}},
+		{name: "exclude replies and retweets", filter: Filter{ExcludeReplies: true, ExcludeRetweets: true}, want: []string{"5", "2", "1"}},
+		{name: "combined", filter: Filter{Since: day(2), Match: regexp.MustCompile(`@bob`), ExcludeRetweets: true}, want: []string{"3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tweet := range a.Query(tt.filter) {
+				got = append(got, tweet.ID)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTweetURL(t *testing.T) {
+	if got, want := (Tweet{ID: "42"}).URL("alice"), "https://twitter.com/alice/status/42"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,229 @@
+// Package audit writes the append-only log of the actions run on Twitter.
+//
+// The log is a JSONL file: one JSON object per action, appended as soon as
+// the action finishes, whether it succeeded or not. Each entry carries the
+// hash of the previous one and its own hash, so that editing, removing or
+// reordering entries breaks the chain. With a key, the hashes are HMACs and
+// cannot be recomputed without it.
+package audit
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/logfile"
+)
+
+// lockTimeout is how long Write waits for another run to finish writing.
+const lockTimeout = 10 * time.Second
+
+// Entry is a line of the audit log.
+type Entry struct {
+	Time        time.Time `json:"time"`
+	User        string    `json:"user"`
+	Account     string    `json:"account"`
+	Action      string    `json:"action"`
+	Target      string    `json:"target,omitempty"`
+	MessageHash string    `json:"message_hash,omitempty"`
+	Outcome     string    `json:"outcome"`
+	URL         string    `json:"url,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Prev        string    `json:"prev"`
+	Hash        string    `json:"hash,omitempty"`
+}
+
+// Log is an audit log file.
+type Log struct {
+	path string
+	key  []byte
+}
+
+// BrokenChainError reports the first entry of a log that does not follow
+// the previous one.
+type BrokenChainError struct {
+	Line   int
+	Reason string
+}
+
+func (e *BrokenChainError) Error() string {
+	return fmt.Sprintf("broken chain at line %d: %s", e.Line, e.Reason)
+}
+
+// Open returns the audit log at path, whose entries are hashed with HMAC-SHA256
+// and key, or with SHA-256 when key is empty. The file is created on the
+// first write.
+func Open(path string, key []byte) *Log {
+	return &Log{path: path, key: key}
+}
+
+// Write appends e to the log, chained to the last entry, setting its time
+// and user when unset. The log is locked from reading the last entry to
+// appending e, so that concurrent runs do not fork the chain.
+func (l *Log) Write(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	if e.User == "" {
+		e.User = osUser()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+
+	unlock, err := logfile.Lock(l.path+".lock", lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+
+	e.Prev, err = lastHash(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	e.Hash, err = l.hash(e)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Verify walks the log and checks that every entry follows the previous one
+// and has not been altered. It returns the number of entries checked, and a
+// *BrokenChainError for the first entry that fails. A missing log has no
+// entries.
+func (l *Log) Verify() (int, error) {
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	prev := ""
+	n := 0
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		n++
+
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return n - 1, &BrokenChainError{Line: n, Reason: fmt.Sprintf("invalid entry: %v", err)}
+		}
+
+		if e.Hash == "" {
+			return n - 1, &BrokenChainError{Line: n, Reason: "entry has no hash"}
+		}
+
+		if e.Prev != prev {
+			return n - 1, &BrokenChainError{Line: n, Reason: "previous hash does not match the previous entry"}
+		}
+
+		want, err := l.hash(e)
+		if err != nil {
+			return n - 1, err
+		}
+
+		if !hmac.Equal([]byte(want), []byte(e.Hash)) {
+			return n - 1, &BrokenChainError{Line: n, Reason: "hash does not match the entry, it was altered or the key is wrong"}
+		}
+
+		prev = e.Hash
+	}
+
+	return n, scanner.Err()
+}
+
+// hash returns the hash of e, computed over its JSON encoding without the
+// hash itself.
+func (l *Log) hash(e Entry) (string, error) {
+	e.Hash = ""
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+
+	var h hash.Hash
+	if len(l.key) > 0 {
+		h = hmac.New(sha256.New, l.key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// lastHash returns the hash of the last entry of f, or an empty string when
+// f is empty.
+func lastHash(f *os.File) (string, error) {
+	last, err := logfile.LastLine(f)
+	if err != nil || last == nil {
+		return "", err
+	}
+
+	var e Entry
+	if err := json.Unmarshal(last, &e); err != nil {
+		return "", fmt.Errorf("reading the last entry of %s: %v", f.Name(), err)
+	}
+
+	return e.Hash, nil
+}
+
+// HashMessage returns the hex SHA-256 of message, so that the log can tell
+// which text was posted without storing it. An empty message has no hash.
+func HashMessage(message string) string {
+	if message == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(message))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// osUser returns the name of the user running the process.
+func osUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	return os.Getenv("USER")
+}
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		verifyKey string
+		tamper    func(lines [][]byte) [][]byte
+		want      int
+		brokenAt  int
+	}{
+		{
+			name: "intact",
+			want: 3,
+		},
+		{
+			name:      "intact with key",
+			key:       "secret",
+			verifyKey: "secret",
+			want:      3,
+		},
+		{
+			name: "modified entry",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[1] = bytes.Replace(lines[1], []byte(`"action":"like"`), []byte(`"action":"follow"`), 1)
+				return lines
+			},
+			want:     1,
+			brokenAt: 2,
+		},
+		{
+			name: "deleted middle entry",
+			tamper: func(lines [][]byte) [][]byte {
+				return append(lines[:1], lines[2:]...)
+			},
+			want:     1,
+			brokenAt: 2,
+		},
+		{
+			name:      "wrong key",
+			key:       "secret",
+			verifyKey: "other",
+			want:      0,
+			brokenAt:  1,
+		},
+		{
+			name:      "key removed",
+			key:       "secret",
+			verifyKey: "",
+			want:      0,
+			brokenAt:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+			log := Open(path, []byte(tt.key))
+			for _, action := range []string{"tweet", "like", "follow"} {
+				if err := log.Write(Entry{Account: "alice", Action: action, Outcome: "success"}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.tamper != nil {
+				raw, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				lines := tt.tamper(bytes.Split(bytes.TrimRight(raw, "\n"), []byte("\n")))
+				if err := os.WriteFile(path, append(bytes.Join(lines, []byte("\n")), '\n'), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			n, err := Open(path, []byte(tt.verifyKey)).Verify()
+			if n != tt.want {
+				t.Errorf("Verify() = %d entries, want %d", n, tt.want)
+			}
+
+			var broken *BrokenChainError
+			switch {
+			case tt.brokenAt == 0 && err != nil:
+				t.Errorf("Verify() error = %v, want none", err)
+			case tt.brokenAt != 0 && !errors.As(err, &broken):
+				t.Errorf("Verify() error = %v, want a broken chain at line %d", err, tt.brokenAt)
+			case tt.brokenAt != 0 && broken.Line != tt.brokenAt:
+				t.Errorf("Verify() broken at line %d, want %d", broken.Line, tt.brokenAt)
+			}
+		})
+	}
+}
+
+func TestWriteLongEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log := Open(path, nil)
+
+	// Longer than the chunks read backwards to find the last entry.
+	long := strings.Repeat("x", 200<<10)
+
+	for _, target := range []string{"a", long, "b"} {
+		if err := log.Write(Entry{Account: "alice", Action: "like", Target: target, Outcome: "success"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n, err := log.Verify(); n != 3 || err != nil {
+		t.Errorf("Verify() = %d, %v, want 3, nil", n, err)
+	}
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	const writers, writes = 8, 25
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A separate Log per writer, as separate runs would have.
+			log := Open(path, nil)
+			for j := 0; j < writes; j++ {
+				if err := log.Write(Entry{Account: "alice", Action: "like", Outcome: "success"}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n, err := Open(path, nil).Verify(); n != writers*writes || err != nil {
+		t.Errorf("Verify() = %d, %v, want %d, nil", n, err, writers*writes)
+	}
+}
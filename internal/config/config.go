@@ -0,0 +1,221 @@
+// Package config edits the accounts of the tweethub configuration file.
+//
+// The file is changed through its YAML node tree rather than decoded and
+// encoded again, so comments, key order and the settings tweethub does not
+// know about are kept.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Account is an entry of the accounts list of the configuration file.
+type Account struct {
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	Alias    string   `yaml:"alias,omitempty"`
+	Label    string   `yaml:"label,omitempty"`
+	Timezone string   `yaml:"timezone,omitempty"`
+	Tags     []string `yaml:"tags,omitempty"`
+}
+
+// File is a configuration file loaded for editing.
+type File struct {
+	path string
+	doc  yaml.Node
+}
+
+// Load reads the configuration file at path. A missing or empty file is
+// an empty configuration.
+func Load(path string) (*File, error) {
+	f := &File{path: path}
+
+	raw, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(raw, &f.doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	if f.doc.Kind == 0 {
+		f.doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	if f.root().Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing %s: the configuration is not a mapping", path)
+	}
+
+	return f, nil
+}
+
+// Add appends account to the accounts list, creating the list if needed. The
+// username and alias must not be used by another account.
+func (f *File) Add(account Account) error {
+	for _, name := range []string{account.Username, account.Alias} {
+		if name == "" {
+			continue
+		}
+		if _, _, err := f.find(name); err == nil {
+			return fmt.Errorf("an account named %q already exists", name)
+		}
+	}
+
+	var node yaml.Node
+	if err := node.Encode(account); err != nil {
+		return err
+	}
+
+	list := f.accounts(true)
+	list.Content = append(list.Content, &node)
+
+	return nil
+}
+
+// SetPassword sets the password of the account with the given username or
+// alias.
+func (f *File) SetPassword(name, password string) error {
+	_, i, err := f.find(name)
+	if err != nil {
+		return err
+	}
+
+	var value yaml.Node
+	value.SetString(password)
+
+	node := f.accounts(false).Content[i]
+	for j := 0; j+1 < len(node.Content); j += 2 {
+		if node.Content[j].Value == "password" {
+			*node.Content[j+1] = value
+			return nil
+		}
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "password"}, &value)
+
+	return nil
+}
+
+// Remove deletes the account with the given username or alias and returns it.
+func (f *File) Remove(name string) (Account, error) {
+	account, i, err := f.find(name)
+	if err != nil {
+		return account, err
+	}
+
+	list := f.accounts(false)
+	list.Content = append(list.Content[:i], list.Content[i+1:]...)
+
+	return account, nil
+}
+
+// Save writes the file back atomically. As it holds passwords, it is only
+// readable and writable by its owner afterwards. When the path is a symbolic
+// link, the file it points to is replaced, not the link.
+func (f *File) Save() error {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&f.doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	path, err := filepath.EvalSymlinks(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		path = f.path
+	} else if err != nil {
+		return err
+	}
+
+	// CreateTemp creates the file with mode 0600.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// find returns the account with the given username or alias and its index
+// in the accounts list.
+func (f *File) find(name string) (Account, int, error) {
+	name = strings.TrimPrefix(name, "@")
+
+	list := f.accounts(false)
+	if list == nil {
+		return Account{}, -1, fmt.Errorf("account %q not found in %s", name, f.path)
+	}
+
+	for i, node := range list.Content {
+		var account Account
+		if err := node.Decode(&account); err != nil {
+			continue
+		}
+
+		if strings.EqualFold(account.Username, name) || (account.Alias != "" && strings.EqualFold(account.Alias, name)) {
+			return account, i, nil
+		}
+	}
+
+	return Account{}, -1, fmt.Errorf("account %q not found in %s", name, f.path)
+}
+
+// accounts returns the sequence node of the accounts list. With create, a
+// missing or empty list is added as a block sequence.
+func (f *File) accounts(create bool) *yaml.Node {
+	root := f.root()
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "accounts" {
+			continue
+		}
+
+		value := root.Content[i+1]
+		if value.Kind != yaml.SequenceNode {
+			if !create {
+				return nil
+			}
+			*value = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", HeadComment: value.HeadComment, LineComment: value.LineComment}
+		}
+		if create {
+			value.Style = 0
+		}
+
+		return value
+	}
+
+	if !create {
+		return nil
+	}
+
+	value := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "accounts"}, value)
+
+	return value
+}
+
+// root returns the top-level node of the document.
+func (f *File) root() *yaml.Node {
+	return f.doc.Content[0]
+}
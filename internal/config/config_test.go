@@ -0,0 +1,245 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sample = `# Accounts used by tweethub.
+accounts:
+  - username: alice # main account
+    password: secret
+    alias: main
+messages:
+  - Hello!
+`
+
+// write creates a configuration file holding content in a new directory.
+func write(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "tweethub.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// read returns the content of the file at path.
+func read(t *testing.T, path string) string {
+	t.Helper()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(raw)
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		account Account
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "keeps comments and other settings",
+			content: sample,
+			account: Account{Username: "bob", Password: "pw", Tags: []string{"work"}},
+			want:    []string{"# Accounts used by tweethub.", "# main account", "- Hello!", "username: bob", "- work"},
+		},
+		{
+			name:    "empty file",
+			account: Account{Username: "bob", Password: "pw"},
+			want:    []string{"accounts:", "username: bob"},
+		},
+		{
+			name:    "empty accounts list",
+			content: "accounts: []\n",
+			account: Account{Username: "bob", Password: "pw"},
+			want:    []string{"  - username: bob"},
+		},
+		{
+			name:    "taken username",
+			content: sample,
+			account: Account{Username: "ALICE"},
+			wantErr: `an account named "ALICE" already exists`,
+		},
+		{
+			name:    "taken alias",
+			content: sample,
+			account: Account{Username: "bob", Alias: "main"},
+			wantErr: `an account named "main" already exists`,
+		},
+		{
+			name:    "alias taken by a username",
+			content: sample,
+			account: Account{Username: "bob", Alias: "alice"},
+			wantErr: `an account named "alice" already exists`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := write(t, tt.content)
+
+			f, err := Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = f.Add(tt.account)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Add() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+
+			if err := f.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := read(t, path)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("saved file lacks %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	path := write(t, sample)
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Remove("bob"); err == nil {
+		t.Error("Remove() of an unknown account succeeded")
+	}
+
+	account, err := f.Remove("@main")
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if account.Username != "alice" || account.Password != "secret" {
+		t.Errorf("Remove() = %+v, want alice", account)
+	}
+
+	if err := f.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := read(t, path)
+	if strings.Contains(got, "alice") || !strings.Contains(got, "- Hello!") {
+		t.Errorf("saved file:\n%s", got)
+	}
+}
+
+func TestSetPassword(t *testing.T) {
+	path := write(t, "accounts:\n  - username: alice\n    password: old\n  - username: bob\n")
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.SetPassword("alice", "new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetPassword("bob", "set"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetPassword("carol", "x"); err == nil {
+		t.Error("SetPassword() of an unknown account succeeded")
+	}
+
+	if err := f.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := read(t, path)
+	if strings.Contains(got, "old") || !strings.Contains(got, "password: new") || !strings.Contains(got, "password: set") {
+		t.Errorf("saved file:\n%s", got)
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+
+	target := filepath.Join(dir, "real.yaml")
+	if err := os.WriteFile(target, []byte(sample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	link := filepath.Join(dir, "tweethub.yaml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skip(err)
+	}
+
+	f, err := Load(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Add(Account{Username: "bob", Password: "pw"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Error("the symbolic link was replaced")
+	}
+
+	info, err = os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("saved file mode = %o, want 600", perm)
+	}
+
+	if got := read(t, target); !strings.Contains(got, "username: bob") {
+		t.Errorf("saved file:\n%s", got)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("the directory holds %d files, want 2", len(entries))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if _, err := Load(write(t, "- a list\n")); err == nil {
+		t.Error("Load() of a list succeeded")
+	}
+
+	f, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() of a missing file error = %v", err)
+	}
+	if _, err := f.Remove("alice"); err == nil {
+		t.Error("Remove() on an empty configuration succeeded")
+	}
+}
@@ -0,0 +1,100 @@
+// Package logfile has the helpers shared by the append-only JSON lines files
+// of tweethub, the history store and the audit log: an exclusive lock held
+// across processes and the lookup of the last line.
+package logfile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// chunkSize is how much of a file LastLine reads at a time, from the end.
+const chunkSize = 64 << 10
+
+// Lock takes an exclusive lock on the file at path, creating it, waiting up
+// to timeout for another process to release it. The lock is an advisory
+// operating system lock, so it is released when the process exits, even
+// after a crash. It returns the function that releases it.
+func Lock(path string, timeout time.Duration) (func(), error) {
+	lock := flock.New(path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	locked, err := lock.TryLockContext(ctx, 50*time.Millisecond)
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, fmt.Errorf("%s is locked by another run", path)
+	}
+
+	return func() { lock.Unlock() }, nil
+}
+
+// Append writes v as a JSON line at the end of the file at path, creating it.
+func Append(path string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// LastLine returns the last non-empty line of f, of any length, reading it
+// backwards from the end. An empty file has no last line and returns nil.
+func LastLine(f *os.File) ([]byte, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var line []byte
+	end := info.Size()
+
+	for end > 0 {
+		start := end - chunkSize
+		if start < 0 {
+			start = 0
+		}
+
+		chunk := make([]byte, end-start)
+		if _, err := f.ReadAt(chunk, start); err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		line = append(chunk, line...)
+
+		trimmed := bytes.TrimRight(line, "\r\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+
+		end = start
+	}
+
+	line = bytes.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return nil, nil
+	}
+
+	return line, nil
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// versionFile holds the schema version of a store directory.
+const versionFile = "version"
+
+// migrations upgrade a store directory one schema version at a time:
+// migrations[i] turns a version i store into a version i+1 store. They run
+// under the lock of the store. New migrations are appended; existing ones
+// must never change.
+var migrations = []func(dir string) error{
+	// 1: one JSON lines file per table.
+	func(dir string) error {
+		for _, table := range []string{actionsTable, postsTable, timelinesTable, snapshotsTable} {
+			f, err := os.OpenFile(filepath.Join(dir, table), os.O_CREATE|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+// SchemaVersion is the schema version of the stores written by this version
+// of tweethub.
+var SchemaVersion = len(migrations)
+
+// migrate brings the store directory dir to SchemaVersion.
+func migrate(dir string) error {
+	version, err := readVersion(dir)
+	if err != nil {
+		return err
+	}
+
+	if version > SchemaVersion {
+		return fmt.Errorf("schema version %d is newer than the supported version %d, upgrade tweethub", version, SchemaVersion)
+	}
+
+	for ; version < SchemaVersion; version++ {
+		if err := migrations[version](dir); err != nil {
+			return fmt.Errorf("migration to version %d: %v", version+1, err)
+		}
+
+		if err := writeFile(filepath.Join(dir, versionFile), []byte(fmt.Sprintln(version+1))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readVersion returns the schema version of the store directory dir, 0 for a
+// new one.
+func readVersion(dir string) (int, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, versionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	version, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version: %v", err)
+	}
+
+	return version, nil
+}
+
+// writeFile replaces the file at path with data atomically.
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	const actions = `{"id":1,"account":"alice","action":"like","target":"1","result":"success"}
+{"id":7,"account":"alice","action":"follow","target":"bob","result":"success"}
+`
+
+	tests := []struct {
+		name    string
+		version string
+		actions string
+		want    []int64
+		wantErr string
+	}{
+		{
+			name: "new store",
+		},
+		{
+			name:    "current version",
+			version: "1\n",
+			actions: actions,
+			want:    []int64{1, 7},
+		},
+		{
+			name:    "records kept by the first migration",
+			actions: actions,
+			want:    []int64{1, 7},
+		},
+		{
+			name:    "newer version",
+			version: "99\n",
+			wantErr: "newer than the supported version",
+		},
+		{
+			name:    "invalid version",
+			version: "one\n",
+			wantErr: "invalid schema version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "tweethub-store")
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.version != "" {
+				if err := os.WriteFile(filepath.Join(dir, versionFile), []byte(tt.version), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.actions != "" {
+				if err := os.WriteFile(filepath.Join(dir, actionsTable), []byte(tt.actions), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			s, err := Open(dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Open() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+
+			if version, err := readVersion(dir); err != nil || version != SchemaVersion {
+				t.Errorf("schema version = %d, %v, want %d", version, err, SchemaVersion)
+			}
+
+			if tt.version == "" {
+				for _, table := range []string{actionsTable, postsTable, timelinesTable, snapshotsTable} {
+					if _, err := os.Stat(filepath.Join(dir, table)); err != nil {
+						t.Errorf("table %s not created: %v", table, err)
+					}
+				}
+			}
+
+			got, err := s.Actions()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d actions, want %d", len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("action %d has ID %d, want %d", i, got[i].ID, id)
+				}
+			}
+
+			// New actions follow the existing ones.
+			if err := s.RecordAction(Action{Account: "alice", Action: "like", Result: "success"}); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err = s.Actions()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := int64(1)
+			if len(tt.want) > 0 {
+				want = tt.want[len(tt.want)-1] + 1
+			}
+			if id := got[len(got)-1].ID; id != want {
+				t.Errorf("new action has ID %d, want %d", id, want)
+			}
+		})
+	}
+}
+
+func TestOpenTwice(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tweethub-store")
+
+	for i := 0; i < 2; i++ {
+		s, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open() #%d error = %v", i+1, err)
+		}
+		if err := s.RecordAction(Action{Account: "alice", Action: "like", Result: "success"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := s.Actions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 || actions[0].ID != 1 || actions[1].ID != 2 {
+		t.Errorf("Actions() = %+v, want IDs 1 and 2", actions)
+	}
+}
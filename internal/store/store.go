@@ -0,0 +1,262 @@
+// Package store keeps the history of tweethub runs in a local directory: the
+// outcome of actions, the tweets created, exported timelines and follower
+// snapshots.
+//
+// Each table is a JSON lines file that records are appended to, so a record
+// costs the same whatever the size of the store. The directory has a schema
+// version and stores written by older versions are migrated when opened.
+// Changes are made under an operating system lock, so that concurrent runs
+// do not lose each other's records and a crashed run never leaves the store
+// locked.
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alomia/tweethub-cli/internal/logfile"
+	"github.com/alomia/tweethub-cli/internal/tweethub"
+)
+
+// lockTimeout is how long to wait for another run to release the store.
+const lockTimeout = 10 * time.Second
+
+// Action is the outcome of an action run on an account.
+type Action struct {
+	ID      int64     `json:"id"`
+	Time    time.Time `json:"time"`
+	Account string    `json:"account"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target,omitempty"`
+	Result  string    `json:"result"`
+	Error   string    `json:"error,omitempty"`
+	URL     string    `json:"url,omitempty"`
+	Undoes  int64     `json:"undoes,omitempty"`
+}
+
+// Post is a tweet created by an account.
+type Post struct {
+	ID        string    `json:"id"`
+	Account   string    `json:"account"`
+	URL       string    `json:"url"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Timeline is an export of the tweets of an account.
+type Timeline struct {
+	Account    string          `json:"account"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Posts      []tweethub.Post `json:"posts"`
+}
+
+// Snapshot is a list of the followers or followed accounts of an account.
+type Snapshot struct {
+	Account string          `json:"account"`
+	Kind    string          `json:"kind"`
+	TakenAt time.Time       `json:"taken_at"`
+	Users   []tweethub.User `json:"users"`
+}
+
+// The tables of the store, one JSON lines file each.
+const (
+	actionsTable   = "actions.jsonl"
+	postsTable     = "posts.jsonl"
+	timelinesTable = "timelines.jsonl"
+	snapshotsTable = "snapshots.jsonl"
+)
+
+// Store is a store directory.
+type Store struct {
+	dir string
+}
+
+// Open opens the store in the directory dir, creating it or migrating it to
+// the current schema version when needed.
+func Open(dir string) (*Store, error) {
+	s := &Store{dir: dir}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := migrate(dir); err != nil {
+		return nil, fmt.Errorf("migrating %s: %v", dir, err)
+	}
+
+	return s, nil
+}
+
+// RecordAction appends a to the actions, assigning its ID and, when unset,
+// its time.
+func (s *Store) RecordAction(a Action) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(s.table(actionsTable), os.O_RDONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	line, err := logfile.LastLine(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	a.ID = 1
+	if line != nil {
+		var last Action
+		if err := json.Unmarshal(line, &last); err != nil {
+			return fmt.Errorf("parsing the last action of %s: %v", s.dir, err)
+		}
+		a.ID = last.ID + 1
+	}
+
+	if a.Time.IsZero() {
+		a.Time = time.Now().UTC()
+	}
+
+	return logfile.Append(s.table(actionsTable), a)
+}
+
+// RecordPost appends p to the created tweets.
+func (s *Store) RecordPost(p Post) error {
+	return s.append(postsTable, p)
+}
+
+// RecordTimeline appends t to the exported timelines.
+func (s *Store) RecordTimeline(t Timeline) error {
+	return s.append(timelinesTable, t)
+}
+
+// RecordSnapshot appends snap to the follower snapshots.
+func (s *Store) RecordSnapshot(snap Snapshot) error {
+	return s.append(snapshotsTable, snap)
+}
+
+// Actions returns the recorded actions, oldest first.
+func (s *Store) Actions() ([]Action, error) {
+	var actions []Action
+
+	err := s.scan(actionsTable, func(line []byte) error {
+		var a Action
+		if err := json.Unmarshal(line, &a); err != nil {
+			return err
+		}
+		actions = append(actions, a)
+		return nil
+	})
+
+	return actions, err
+}
+
+// Posts returns the recorded tweets created by account, oldest first. An
+// empty account returns the tweets of every account.
+func (s *Store) Posts(account string) ([]Post, error) {
+	var posts []Post
+
+	err := s.scan(postsTable, func(line []byte) error {
+		var p Post
+		if err := json.Unmarshal(line, &p); err != nil {
+			return err
+		}
+		if account == "" || strings.EqualFold(p.Account, account) {
+			posts = append(posts, p)
+		}
+		return nil
+	})
+
+	return posts, err
+}
+
+// Snapshots returns the recorded snapshots of the given kind for account,
+// oldest first.
+func (s *Store) Snapshots(account, kind string) ([]Snapshot, error) {
+	var snaps []Snapshot
+
+	err := s.scan(snapshotsTable, func(line []byte) error {
+		var snap Snapshot
+		if err := json.Unmarshal(line, &snap); err != nil {
+			return err
+		}
+		if strings.EqualFold(snap.Account, account) && snap.Kind == kind {
+			snaps = append(snaps, snap)
+		}
+		return nil
+	})
+
+	return snaps, err
+}
+
+// append appends v to table under the lock.
+func (s *Store) append(table string, v any) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return logfile.Append(s.table(table), v)
+}
+
+// scan calls fn with each complete line of table. A last line without its
+// newline is being written by another run and is skipped.
+func (s *Store) scan(table string, fn func(line []byte) error) error {
+	f, err := os.Open(s.table(table))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := fn(line); err != nil {
+			return fmt.Errorf("parsing %s line %d: %v", s.table(table), n, err)
+		}
+	}
+}
+
+// table returns the path of the file of table.
+func (s *Store) table(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// lock takes the lock of the store, waiting up to lockTimeout for another
+// run to release it. It returns the function that releases it.
+func (s *Store) lock() (func(), error) {
+	return logfile.Lock(filepath.Join(s.dir, "lock"), lockTimeout)
+}
@@ -0,0 +1,161 @@
+package tweethub
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Block blocks the specified Twitter username.
+func (t TweetHub) Block(username string) context.CancelFunc {
+	return t.relation(username, "block", "Blocked", "blocked", (*Session).Block)
+}
+
+// UnBlock unblocks the specified Twitter username.
+func (t TweetHub) UnBlock(username string) context.CancelFunc {
+	return t.relation(username, "unblock", "Unblocked", "not blocked", (*Session).UnBlock)
+}
+
+// Mute mutes the specified Twitter username.
+func (t TweetHub) Mute(username string) context.CancelFunc {
+	return t.relation(username, "mute", "Muted", "muted", (*Session).Mute)
+}
+
+// UnMute unmutes the specified Twitter username.
+func (t TweetHub) UnMute(username string) context.CancelFunc {
+	return t.relation(username, "unmute", "Unmuted", "not muted", (*Session).UnMute)
+}
+
+// relation logs in and runs a single block or mute action, reporting the outcome.
+func (t TweetHub) relation(username, action, done, state string, fn func(*Session, string) (bool, error)) context.CancelFunc {
+	s, cancel, err := t.NewSession()
+	if err != nil {
+		t.record(ActionRecord{Action: action, Target: username}, err)
+		fmt.Printf("Failed to %s @%s: %v\n", action, username, err)
+		return cancel
+	}
+
+	changed, err := fn(s, username)
+
+	switch {
+	case err != nil:
+		fmt.Printf("Failed to %s @%s: %v\n", action, username, err)
+	case !changed:
+		fmt.Printf("@%s is already %s\n", username, state)
+	default:
+		fmt.Printf("%s @%s successfully\n", done, username)
+	}
+
+	return cancel
+}
+
+// Block blocks the specified username. It reports false without doing
+// anything when the user is already blocked.
+func (s *Session) Block(username string) (bool, error) {
+	changed, err := s.setRelation("block", username, fmt.Sprintf("Blocked @%s", username), true, "block", true)
+	return s.recordChange("block", username, changed, err)
+}
+
+// UnBlock unblocks the specified username. It reports false without doing
+// anything when the user is not blocked.
+func (s *Session) UnBlock(username string) (bool, error) {
+	changed, err := s.setRelation("unblock", username, fmt.Sprintf("Blocked @%s", username), false, "", true)
+	return s.recordChange("unblock", username, changed, err)
+}
+
+// Mute mutes the specified username. It reports false without doing
+// anything when the user is already muted.
+func (s *Session) Mute(username string) (bool, error) {
+	changed, err := s.setRelation("mute", username, fmt.Sprintf("Unmute @%s", username), true, "mute", false)
+	return s.recordChange("mute", username, changed, err)
+}
+
+// UnMute unmutes the specified username. It reports false without doing
+// anything when the user is not muted.
+func (s *Session) UnMute(username string) (bool, error) {
+	changed, err := s.setRelation("unmute", username, fmt.Sprintf("Unmute @%s", username), false, "", false)
+	return s.recordChange("unmute", username, changed, err)
+}
+
+// setRelation opens the profile of username and brings it to the wanted state
+// with action. The current state is given by the presence of the button
+// labelled stateLabel. The state is set through the profile menu entry
+// menuItem and cleared by clicking that button, confirming when confirm is
+// set. In dry-run mode, action is only planned, without opening the profile.
+func (s *Session) setRelation(action, username, stateLabel string, want bool, menuItem string, confirm bool) (bool, error) {
+	if s.hub.planned(action, "@"+username) {
+		return true, nil
+	}
+
+	profileURL, _ := url.JoinPath(twitterURL, username)
+
+	userActionsSelector := `//div[@data-testid="userActions"]`
+	stateButtonSelector := fmt.Sprintf(`//div[@role="button"][@aria-label="%s"]`, stateLabel)
+	menuItemSelector := fmt.Sprintf(`//div[@role="menu"]//div[@role="menuitem"][@data-testid="%s"]`, menuItem)
+	confirmSelector := `//div[@data-testid="confirmationSheetConfirm"]`
+
+	var current bool
+
+	err := s.run(
+		chromedp.Navigate(profileURL),
+
+		chromedp.WaitVisible(userActionsSelector, chromedp.BySearch),
+		chromedp.Evaluate(fmt.Sprintf(`!!document.querySelector('[aria-label="%s"]')`, stateLabel), &current),
+	)
+	if err != nil || current == want {
+		return false, err
+	}
+
+	var tasks chromedp.Tasks
+
+	if want {
+		tasks = chromedp.Tasks{
+			chromedp.Click(userActionsSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.Click(menuItemSelector, chromedp.BySearch, chromedp.NodeVisible),
+		}
+	} else {
+		tasks = chromedp.Tasks{
+			chromedp.Click(stateButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+		}
+	}
+
+	if confirm {
+		tasks = append(tasks, chromedp.Click(confirmSelector, chromedp.BySearch, chromedp.NodeVisible))
+	}
+
+	if want {
+		tasks = append(tasks, chromedp.WaitVisible(stateButtonSelector, chromedp.BySearch))
+	} else {
+		tasks = append(tasks, chromedp.WaitNotPresent(stateButtonSelector, chromedp.BySearch))
+	}
+
+	return true, s.run(tasks)
+}
+
+// Blocked returns the usernames blocked by the account.
+func (s *Session) Blocked() ([]string, error) {
+	return s.usernames(twitterURL + "/settings/blocked/all")
+}
+
+// Muted returns the usernames muted by the account.
+func (s *Session) Muted() ([]string, error) {
+	return s.usernames(twitterURL + "/settings/muted/all")
+}
+
+// usernames collects the usernames of every user listed on pageURL.
+func (s *Session) usernames(pageURL string) ([]string, error) {
+	if err := s.ready(); err != nil {
+		return nil, err
+	}
+
+	var usernames []string
+
+	err := scrollUsers(s.ctx, pageURL, 0, func(user User) bool {
+		usernames = append(usernames, user.Username)
+		return true
+	})
+
+	return usernames, err
+}
@@ -0,0 +1,142 @@
+package tweethub
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/chromedp/chromedp"
+)
+
+// focalTweetSelector matches the tweet a status page is about. It is used
+// instead of matching the status ID because the page of a repost shows the
+// original tweet.
+const focalTweetSelector = `//article[@data-testid="tweet"][@tabindex="-1"]`
+
+// UnTweet deletes the tweet of the account at tweetURL, which may also be a
+// bare status ID.
+func (s *Session) UnTweet(tweetURL string) error {
+	if s.hub.plannedTweet("untweet", tweetURL) {
+		return nil
+	}
+
+	err := s.unTweet(tweetURL)
+	s.hub.record(ActionRecord{Action: "untweet", Target: tweetURL}, err)
+
+	return err
+}
+
+// unTweet deletes the tweet from the menu of its page.
+func (s *Session) unTweet(tweetURL string) error {
+	ref, err := ParseTweetRef(tweetURL)
+	if err != nil {
+		return err
+	}
+
+	tweetSelector := fmt.Sprintf(`//article[@data-testid="tweet"][.//a[contains(@href, "/status/%s")]]`, ref.ID)
+	moreSelector := tweetSelector + `//div[@data-testid="caret"]`
+	menuItemSelector := `//div[@role="menu"]//div[@role="menuitem"][.//span[text()="Delete"]]`
+	confirmSelector := `//div[@data-testid="confirmationSheetConfirm"]`
+
+	return s.run(
+		chromedp.Navigate(ref.URL()),
+
+		chromedp.WaitVisible(moreSelector, chromedp.BySearch),
+		chromedp.Click(moreSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(menuItemSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(confirmSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+		chromedp.WaitNotPresent(confirmSelector, chromedp.BySearch),
+	)
+}
+
+// UnLike removes the like of the account from the tweet at tweetURL, which
+// may also be a bare status ID. Nothing is done when it is not liked.
+func (s *Session) UnLike(tweetURL string) error {
+	if s.hub.plannedTweet("unlike", tweetURL) {
+		return nil
+	}
+
+	changed, err := s.undoAction(tweetURL, "unlike", "like", "")
+	_, err = s.recordChange("unlike", tweetURL, changed, err)
+
+	return err
+}
+
+// UnRepost removes the repost of the account of the tweet at tweetURL, which
+// may also be a bare status ID. Nothing is done when it is not reposted.
+func (s *Session) UnRepost(tweetURL string) error {
+	if s.hub.plannedTweet("unrepost", tweetURL) {
+		return nil
+	}
+
+	changed, err := s.undoAction(tweetURL, "unretweet", "retweet", `//div[@role="menu"]//div[@data-testid="unretweetConfirm"]`)
+	_, err = s.recordChange("unrepost", tweetURL, changed, err)
+
+	return err
+}
+
+// undoAction clicks the undoButton of the focal tweet at tweetURL, then
+// confirmSelector when given, and waits for doButton to replace it. It reports
+// false without doing anything when the tweet has no undoButton.
+func (s *Session) undoAction(tweetURL, undoButton, doButton, confirmSelector string) (bool, error) {
+	ref, err := ParseTweetRef(tweetURL)
+	if err != nil {
+		return false, err
+	}
+
+	undoSelector := fmt.Sprintf(`%s//div[@data-testid="%s"]`, focalTweetSelector, undoButton)
+	doSelector := fmt.Sprintf(`%s//div[@data-testid="%s"]`, focalTweetSelector, doButton)
+
+	var active bool
+
+	err = s.run(
+		chromedp.Navigate(ref.URL()),
+
+		chromedp.WaitVisible(focalTweetSelector, chromedp.BySearch),
+		chromedp.Evaluate(fmt.Sprintf(`!!document.querySelector('article[data-testid="tweet"][tabindex="-1"] [data-testid="%s"]')`, undoButton), &active),
+	)
+	if err != nil || !active {
+		return false, err
+	}
+
+	tasks := chromedp.Tasks{
+		chromedp.Click(undoSelector, chromedp.BySearch, chromedp.NodeVisible),
+	}
+
+	if confirmSelector != "" {
+		tasks = append(tasks, chromedp.Click(confirmSelector, chromedp.BySearch, chromedp.NodeVisible))
+	}
+
+	return true, s.run(append(tasks, chromedp.WaitVisible(doSelector, chromedp.BySearch)))
+}
+
+// Likes scrolls the likes tab of username, calling fn once for every tweet it
+// liked, most recently liked first. It stops when fn returns false or the end
+// of the tab is reached.
+func (s *Session) Likes(username string, fn func(Post) bool) error {
+	likesURL, _ := url.JoinPath(twitterURL, username, "likes")
+
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	return scrollTimeline(s.ctx, likesURL, 0, fn)
+}
+
+// Reposts scrolls the profile timeline of username, calling fn once for every
+// tweet of another account it reposted. It stops like Likes.
+func (s *Session) Reposts(username string, fn func(Post) bool) error {
+	profileURL, _ := url.JoinPath(twitterURL, username)
+
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	return scrollTimeline(s.ctx, profileURL, 0, func(post Post) bool {
+		if !post.Repost {
+			return true
+		}
+
+		return fn(post)
+	})
+}
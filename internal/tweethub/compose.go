@@ -0,0 +1,180 @@
+package tweethub
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// ReplySettings controls who can reply to a composed tweet.
+type ReplySettings string
+
+const (
+	ReplyEveryone  ReplySettings = "everyone"
+	ReplyFollowing ReplySettings = "following"
+	ReplyMentioned ReplySettings = "mentioned"
+)
+
+// replySettingsLabels maps each reply setting to its entry in the composer menu.
+var replySettingsLabels = map[ReplySettings]string{
+	ReplyEveryone:  "Everyone",
+	ReplyFollowing: "Accounts you follow",
+	ReplyMentioned: "Only accounts you mention",
+}
+
+// MaxScheduleAhead is how far in the future the composer allows scheduling.
+const MaxScheduleAhead = 18 * 30 * 24 * time.Hour
+
+// scheduleLayouts are the layouts accepted by ParseScheduleTime.
+var scheduleLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+// ParseReplySettings parses one of "everyone", "following" or "mentioned".
+func ParseReplySettings(s string) (ReplySettings, error) {
+	settings := ReplySettings(s)
+
+	if _, ok := replySettingsLabels[settings]; !ok {
+		return "", fmt.Errorf("invalid reply settings %q, expected everyone, following or mentioned", s)
+	}
+
+	return settings, nil
+}
+
+// ParseScheduleTime parses the time a tweet should be published at.
+// Times without a zone are interpreted in the local time zone.
+// It fails unless the time lies between now and MaxScheduleAhead.
+func ParseScheduleTime(s string) (time.Time, error) {
+	for _, layout := range scheduleLayouts {
+		at, err := time.ParseInLocation(layout, s, time.Local)
+		if err != nil {
+			continue
+		}
+
+		now := time.Now()
+
+		switch {
+		case !at.After(now):
+			return time.Time{}, fmt.Errorf("schedule time %s is in the past", s)
+		case at.After(now.Add(MaxScheduleAhead)):
+			return time.Time{}, fmt.Errorf("schedule time %s is too far in the future", s)
+		}
+
+		return at.Local(), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid schedule time %q, expected YYYY-MM-DD HH:MM or RFC 3339", s)
+}
+
+// SetReplySettings sets who can reply to the tweets created by Tweet and Quote.
+func (t *TweetHub) SetReplySettings(settings ReplySettings) {
+	t.replySettings = settings
+}
+
+// SetSensitive marks the media attached to the tweets created by Tweet and Quote as sensitive.
+func (t *TweetHub) SetSensitive(sensitive bool) {
+	t.sensitive = sensitive
+}
+
+// SetScheduleAt schedules the tweets created by Tweet and Quote for the given time
+// instead of posting them immediately. A zero time posts immediately.
+func (t *TweetHub) SetScheduleAt(at time.Time) {
+	t.scheduleAt = at
+}
+
+// composeOptions returns the actions that apply the sensitive flag, the reply
+// settings and the schedule to the composer found under scope.
+func (t TweetHub) composeOptions(scope string) chromedp.Tasks {
+	var tasks chromedp.Tasks
+
+	if t.sensitive && len(t.media) > 0 {
+		tasks = append(tasks, markSensitive(scope))
+	}
+
+	if t.replySettings != "" {
+		tasks = append(tasks, setReplySettings(scope, t.replySettings))
+	}
+
+	if !t.scheduleAt.IsZero() {
+		tasks = append(tasks, schedule(scope, t.scheduleAt))
+	}
+
+	return tasks
+}
+
+// markSensitive flags the attached media as sensitive content through the media editor.
+func markSensitive(scope string) chromedp.Tasks {
+	editMediaSelector := fmt.Sprintf(`(%s//div[@data-testid="attachments"]//a[@data-testid="altTextLabel"])[1]`, scope)
+	contentWarningTabSelector := `//div[@role="dialog"]//div[@role="tab"][.//span[text()="Content warning"]]`
+	sensitiveCheckboxSelector := `//div[@role="dialog"]//input[@type="checkbox"][@aria-label="Sensitive"]`
+	saveSelector := `//div[@role="dialog"]//div[@data-testid="endEditingButton"]`
+
+	return chromedp.Tasks{
+		chromedp.Click(editMediaSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(contentWarningTabSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(sensitiveCheckboxSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(saveSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.WaitNotPresent(sensitiveCheckboxSelector, chromedp.BySearch),
+	}
+}
+
+// setReplySettings picks who can reply from the composer reply menu.
+func setReplySettings(scope string, settings ReplySettings) chromedp.Tasks {
+	replyButtonSelector := scope + `//div[@role="button"][contains(@aria-label, "can reply")]`
+	menuItemSelector := fmt.Sprintf(`//div[@role="menu"]//div[@role="menuitem"][.//span[text()="%s"]]`, replySettingsLabels[settings])
+
+	return chromedp.Tasks{
+		chromedp.Click(replyButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(menuItemSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.WaitNotPresent(menuItemSelector, chromedp.BySearch),
+	}
+}
+
+// schedule fills in the composer scheduling dialog with the given time.
+// The composer switches its post button to "Schedule" once it is confirmed.
+func schedule(scope string, at time.Time) chromedp.Tasks {
+	scheduleButtonSelector := scope + `//div[@data-testid="scheduleOption"]`
+	confirmSelector := `//div[@role="dialog"]//div[@data-testid="scheduledConfirmationPrimaryAction"]`
+
+	hour, meridiem := at.Hour()%12, "AM"
+	if at.Hour() >= 12 {
+		meridiem = "PM"
+	}
+	if hour == 0 {
+		hour = 12
+	}
+
+	fields := []struct {
+		label string
+		value string
+	}{
+		{"Month", at.Month().String()},
+		{"Day", strconv.Itoa(at.Day())},
+		{"Year", strconv.Itoa(at.Year())},
+		{"Hour", strconv.Itoa(hour)},
+		{"Minute", fmt.Sprintf("%02d", at.Minute())},
+		{"AM/PM", meridiem},
+	}
+
+	tasks := chromedp.Tasks{
+		chromedp.Click(scheduleButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+	}
+
+	for _, field := range fields {
+		selectSelector := fmt.Sprintf(`//div[@role="dialog"]//label[.//span[text()="%s"]]/following-sibling::select`, field.label)
+
+		tasks = append(tasks,
+			chromedp.WaitVisible(selectSelector, chromedp.BySearch),
+			chromedp.SendKeys(selectSelector, field.value, chromedp.BySearch),
+		)
+	}
+
+	return append(tasks,
+		chromedp.Click(confirmSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.WaitNotPresent(confirmSelector, chromedp.BySearch),
+	)
+}
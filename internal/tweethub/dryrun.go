@@ -0,0 +1,98 @@
+package tweethub
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetDryRun makes the actions of the TweetHub instance and of its sessions
+// print what they would do instead of doing it. No browser is started for
+// them; sessions only start one when something has to be read from Twitter.
+func (t *TweetHub) SetDryRun(dryRun bool) {
+	t.dryRun = dryRun
+	t.invalidPlans = new(int)
+}
+
+// InvalidPlans returns the number of actions planned in dry-run mode that
+// failed validation and would not run.
+func (t TweetHub) InvalidPlans() int {
+	if t.invalidPlans == nil {
+		return 0
+	}
+
+	return *t.invalidPlans
+}
+
+// planned prints, in dry-run mode, the action that would be run on target
+// with its details, and reports whether the action must be skipped.
+func (t TweetHub) planned(action, target string, details ...string) bool {
+	if !t.dryRun {
+		return false
+	}
+
+	line := fmt.Sprintf("[dry-run] @%s: %s", t.username, action)
+	if target != "" {
+		line += " " + target
+	}
+
+	invalid := false
+	for _, detail := range details {
+		line += "\n    " + detail
+		invalid = invalid || strings.HasPrefix(detail, "invalid: ")
+	}
+
+	if invalid && t.invalidPlans != nil {
+		*t.invalidPlans++
+	}
+
+	fmt.Println(line)
+
+	return true
+}
+
+// plannedTweet is planned for an action on the tweet at tweetURL, which is
+// checked to be a valid tweet reference.
+func (t TweetHub) plannedTweet(action, tweetURL string, details ...string) bool {
+	if _, err := ParseTweetRef(tweetURL); err != nil {
+		details = append(details, "invalid: "+err.Error())
+	}
+
+	return t.planned(action, tweetURL, details...)
+}
+
+// composeDetails describes the message and compose options of a new tweet.
+func (t TweetHub) composeDetails(message string) []string {
+	details := []string{fmt.Sprintf("message (%d/%d characters): %q", Length(message), MaxTweetLength, message)}
+
+	if Length(message) > MaxTweetLength {
+		details = append(details, "invalid: the message is too long")
+	}
+
+	for _, m := range t.media {
+		kind, _ := m.Kind()
+		detail := fmt.Sprintf("media: %s (%s)", m.Path, kind)
+		if m.Alt != "" {
+			detail += fmt.Sprintf(", alt %q", m.Alt)
+		}
+		details = append(details, detail)
+	}
+
+	if t.poll != nil {
+		details = append(details, fmt.Sprintf("poll: %s for %s", strings.Join(t.poll.Options, " / "), t.poll.Duration))
+	}
+
+	if t.sensitive {
+		details = append(details, "media marked as sensitive")
+	}
+
+	if t.replySettings != "" {
+		details = append(details, fmt.Sprintf("replies: %s", t.replySettings))
+	}
+
+	if !t.scheduleAt.IsZero() {
+		details = append(details, fmt.Sprintf("scheduled for %s", t.scheduleAt.Format(time.RFC1123)))
+	}
+
+	return details
+}
@@ -0,0 +1,26 @@
+package tweethub
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// MaxTweetLength is the maximum weighted length of a tweet.
+const MaxTweetLength = 280
+
+// urlLength is the weighted length of any link, which Twitter shortens with t.co.
+const urlLength = 23
+
+var urlPattern = regexp.MustCompile(`https?://\S+`)
+
+// Length returns the weighted length of message as counted by Twitter,
+// where every link counts as 23 characters regardless of its size.
+func Length(message string) int {
+	length := 0
+
+	for _, link := range urlPattern.FindAllString(message, -1) {
+		length += urlLength - utf8.RuneCountInString(link)
+	}
+
+	return length + utf8.RuneCountInString(message)
+}
@@ -0,0 +1,31 @@
+package tweethub
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    int
+	}{
+		{name: "empty", message: "", want: 0},
+		{name: "plain", message: "Hello, world!", want: 13},
+		{name: "accents", message: "¡Hola, mañana!", want: 14},
+		{name: "hashtags", message: "#launch #news", want: 13},
+		{name: "short link", message: "https://t.co/x", want: 23},
+		{name: "long link", message: "https://example.com/" + strings.Repeat("a", 100), want: 23},
+		{name: "text and links", message: "See http://a.co and https://b.co/path", want: len("See  and ") + 2*23},
+		{name: "limit", message: strings.Repeat("a", MaxTweetLength), want: MaxTweetLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Length(tt.message); got != tt.want {
+				t.Errorf("Length(%q) = %d, want %d", tt.message, got, tt.want)
+			}
+		})
+	}
+}
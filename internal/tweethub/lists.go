@@ -0,0 +1,278 @@
+package tweethub
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+var listIDPattern = regexp.MustCompile(`^(?:https?://(?:www\.)?(?:twitter|x)\.com)?/?i/lists/(\d+)|^(\d+)$`)
+
+// scrapeListsJS collects the lists shown on the lists page of an account.
+const scrapeListsJS = `Array.from(document.querySelectorAll('[data-testid="primaryColumn"] a[href^="/i/lists/"]')).map(link => ({
+	id: link.getAttribute('href').split('/')[3] || "",
+	name: link.innerText.split('\n')[0],
+}))`
+
+// list is a Twitter list owned by the account.
+type list struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateList creates a new list with the given name and description.
+func (t TweetHub) CreateList(name, description string, private bool) context.CancelFunc {
+	if t.planned("create list", fmt.Sprintf("%q", name), fmt.Sprintf("description: %q", description), fmt.Sprintf("private: %t", private)) {
+		return func() {}
+	}
+
+	createListURL := twitterURL + "/i/lists/create"
+
+	nameInputSelector := `//div[@role="dialog"]//input[@name="name"]`
+	descriptionSelector := `//div[@role="dialog"]//textarea[@name="description"]`
+	privateSelector := `//div[@role="dialog"]//input[@type="checkbox"]`
+	nextButtonSelector := `//div[@role="dialog"]//div[@data-testid="listCreateNextButton"]`
+	doneButtonSelector := `//div[@role="dialog"]//div[@role="button"][.//span[text()="Done"]]`
+
+	var listURL string
+
+	ctx, cancel := t.Login()
+
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(createListURL),
+
+		chromedp.WaitVisible(nameInputSelector, chromedp.BySearch),
+		chromedp.SendKeys(nameInputSelector, name, chromedp.BySearch),
+		chromedp.SendKeys(descriptionSelector, description, chromedp.BySearch),
+		setCheckbox(privateSelector, private),
+		chromedp.Click(nextButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+		chromedp.Click(doneButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.WaitNotPresent(doneButtonSelector, chromedp.BySearch),
+		chromedp.Location(&listURL),
+	)
+
+	t.record(ActionRecord{Action: "create-list", Target: name, URL: listURL}, err)
+
+	if err != nil {
+		fmt.Printf("Failed to create list %q: %v\n", name, err)
+	} else {
+		fmt.Printf("Created list %q successfully: %s\n", name, listURL)
+	}
+
+	return cancel
+}
+
+// DeleteList deletes the list identified by its name, ID or URL.
+func (t TweetHub) DeleteList(listRef string) context.CancelFunc {
+	if t.planned("delete list", fmt.Sprintf("%q", listRef)) {
+		return func() {}
+	}
+
+	editButtonSelector := `//a[contains(@href, "/info")][.//span[text()="Edit List"]]`
+	deleteButtonSelector := `//div[@role="dialog"]//div[@role="button"][.//span[text()="Delete List"]]`
+	confirmSelector := `//div[@data-testid="confirmationSheetConfirm"]`
+
+	ctx, cancel := t.Login()
+
+	l, err := t.findList(ctx, listRef)
+	if err == nil {
+		err = chromedp.Run(ctx,
+			chromedp.Navigate(l.url()),
+
+			chromedp.Click(editButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.Click(deleteButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.Click(confirmSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+			chromedp.WaitNotPresent(confirmSelector, chromedp.BySearch),
+		)
+	}
+
+	t.record(ActionRecord{Action: "delete-list", Target: listRef}, err)
+
+	if err != nil {
+		fmt.Printf("Failed to delete list %q: %v\n", listRef, err)
+	} else {
+		fmt.Printf("Deleted list %q successfully\n", listRef)
+	}
+
+	return cancel
+}
+
+// RenameList renames the list identified by its name, ID or URL.
+func (t TweetHub) RenameList(listRef, newName string) context.CancelFunc {
+	if t.planned("rename list", fmt.Sprintf("%q", listRef), fmt.Sprintf("new name: %q", newName)) {
+		return func() {}
+	}
+
+	editButtonSelector := `//a[contains(@href, "/info")][.//span[text()="Edit List"]]`
+	nameInputSelector := `//div[@role="dialog"]//input[@name="name"]`
+	saveButtonSelector := `//div[@role="dialog"]//div[@role="button"][.//span[text()="Save"]]`
+
+	ctx, cancel := t.Login()
+
+	l, err := t.findList(ctx, listRef)
+	if err == nil {
+		err = chromedp.Run(ctx,
+			chromedp.Navigate(l.url()),
+
+			chromedp.Click(editButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.WaitVisible(nameInputSelector, chromedp.BySearch),
+			replaceText(nameInputSelector, newName),
+			chromedp.Click(saveButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+			chromedp.WaitNotPresent(nameInputSelector, chromedp.BySearch),
+		)
+	}
+
+	t.record(ActionRecord{Action: "rename-list", Target: listRef, Message: newName}, err)
+
+	if err != nil {
+		fmt.Printf("Failed to rename list %q: %v\n", listRef, err)
+	} else {
+		fmt.Printf("Renamed list %q to %q successfully\n", listRef, newName)
+	}
+
+	return cancel
+}
+
+// AddListMember adds username to the list identified by its name, ID or URL.
+// Nothing is changed when the user is already a member.
+func (t TweetHub) AddListMember(listRef, username string) context.CancelFunc {
+	return t.setListMember(listRef, username, true)
+}
+
+// RemoveListMember removes username from the list identified by its name, ID
+// or URL. Nothing is changed when the user is not a member.
+func (t TweetHub) RemoveListMember(listRef, username string) context.CancelFunc {
+	return t.setListMember(listRef, username, false)
+}
+
+// setListMember toggles the membership of username in the list from the
+// "Add/remove from Lists" dialog of their profile, if it differs from member.
+func (t TweetHub) setListMember(listRef, username string, member bool) context.CancelFunc {
+	action, preposition, done, state := "add", "to", "Added", "already a member of"
+	if !member {
+		action, preposition, done, state = "remove", "from", "Removed", "not a member of"
+	}
+
+	if t.planned(fmt.Sprintf("%s @%s %s list", action, username, preposition), fmt.Sprintf("%q", listRef)) {
+		return func() {}
+	}
+
+	profileURL, _ := url.JoinPath(twitterURL, username)
+
+	userActionsSelector := `//div[@data-testid="userActions"]`
+	menuItemSelector := `//div[@role="menu"]//div[@role="menuitem"][.//span[text()="Add/remove from Lists"]]`
+	saveButtonSelector := `//div[@role="dialog"]//div[@role="button"][.//span[text()="Save"]]`
+
+	ctx, cancel := t.Login()
+
+	l, err := t.findList(ctx, listRef)
+
+	changed := false
+
+	if err == nil {
+		listSelector := fmt.Sprintf(`//div[@role="dialog"]//div[@role="checkbox"][.//span[text()=%s]]`, xpathLiteral(l.Name))
+
+		var checked string
+		var ok bool
+
+		err = chromedp.Run(ctx,
+			chromedp.Navigate(profileURL),
+
+			chromedp.Click(userActionsSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.Click(menuItemSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.WaitVisible(listSelector, chromedp.BySearch),
+			chromedp.AttributeValue(listSelector, "aria-checked", &checked, &ok, chromedp.BySearch),
+		)
+
+		if err == nil && (checked == "true") != member {
+			changed = true
+
+			err = chromedp.Run(ctx,
+				chromedp.Click(listSelector, chromedp.BySearch, chromedp.NodeVisible),
+				chromedp.Click(saveButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+				chromedp.WaitNotPresent(saveButtonSelector, chromedp.BySearch),
+			)
+		}
+	}
+
+	r := ActionRecord{Action: action + "-list-member", Target: listRef + " @" + username}
+	if !changed {
+		r.Outcome = OutcomeSkipped
+	}
+	t.record(r, err)
+
+	switch {
+	case err != nil:
+		fmt.Printf("Failed to %s @%s %s list %q: %v\n", action, username, preposition, listRef, err)
+	case !changed:
+		fmt.Printf("@%s is %s list %q\n", username, state, listRef)
+	default:
+		fmt.Printf("%s @%s %s list %q successfully\n", done, username, preposition, listRef)
+	}
+
+	return cancel
+}
+
+// ListMembers returns the members of the list identified by its name, ID or URL.
+func (t TweetHub) ListMembers(listRef string) ([]User, context.CancelFunc, error) {
+	ctx, cancel := t.Login()
+
+	l, err := t.findList(ctx, listRef)
+	if err != nil {
+		return nil, cancel, err
+	}
+
+	var members []User
+
+	err = scrollUsers(ctx, l.url()+"/members", 0, func(user User) bool {
+		members = append(members, user)
+		return true
+	})
+
+	return members, cancel, err
+}
+
+// findList resolves a list ID, list URL or the name of one of the lists of
+// the account.
+func (t TweetHub) findList(ctx context.Context, listRef string) (list, error) {
+	listsURL, _ := url.JoinPath(twitterURL, t.username, "lists")
+
+	var id string
+	if m := listIDPattern.FindStringSubmatch(listRef); m != nil {
+		id = m[1] + m[2]
+	}
+
+	var found *list
+
+	err := scroll(ctx, listsURL, `a[href^="/i/lists/"]`, scrapeListsJS, func(l list) string {
+		return l.ID
+	}, 0, func(l list) bool {
+		if l.ID == id || strings.EqualFold(l.Name, listRef) {
+			found = &l
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return list{}, err
+	}
+
+	if found == nil {
+		return list{}, fmt.Errorf("list %q not found", listRef)
+	}
+
+	return *found, nil
+}
+
+// url returns the URL of the list page.
+func (l list) url() string {
+	return twitterURL + "/i/lists/" + l.ID
+}
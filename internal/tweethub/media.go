@@ -0,0 +1,163 @@
+package tweethub
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Limits applied to media attachments before they are uploaded.
+const (
+	MaxImages      = 4
+	MaxImageSize   = 5 << 20
+	MaxGIFSize     = 15 << 20
+	MaxVideoSize   = 512 << 20
+	MaxAltTextSize = 1000
+)
+
+// MediaKind identifies the type of a media attachment.
+type MediaKind string
+
+const (
+	MediaImage MediaKind = "image"
+	MediaGIF   MediaKind = "gif"
+	MediaVideo MediaKind = "video"
+)
+
+var mediaKinds = map[string]MediaKind{
+	".jpg":  MediaImage,
+	".jpeg": MediaImage,
+	".png":  MediaImage,
+	".webp": MediaImage,
+	".gif":  MediaGIF,
+	".mp4":  MediaVideo,
+	".mov":  MediaVideo,
+}
+
+// Media represents a file attached to a tweet along with its alt text.
+type Media struct {
+	Path string
+	Alt  string
+}
+
+// Kind returns the kind of the media based on its file extension.
+func (m Media) Kind() (MediaKind, error) {
+	ext := strings.ToLower(filepath.Ext(m.Path))
+
+	kind, ok := mediaKinds[ext]
+	if !ok {
+		return "", fmt.Errorf("unsupported media type %q for %s", ext, m.Path)
+	}
+
+	return kind, nil
+}
+
+// ValidateMedia checks the attachments against the count, type and size
+// limits enforced by Twitter, so invalid uploads fail before the browser starts.
+func ValidateMedia(media []Media) error {
+	if len(media) == 0 {
+		return nil
+	}
+
+	var images, others int
+
+	for _, m := range media {
+		kind, err := m.Kind()
+		if err != nil {
+			return err
+		}
+
+		info, err := os.Stat(m.Path)
+		if err != nil {
+			return fmt.Errorf("cannot read media %s: %v", m.Path, err)
+		}
+
+		if info.IsDir() {
+			return fmt.Errorf("media %s is a directory", m.Path)
+		}
+
+		limit := int64(MaxImageSize)
+
+		switch kind {
+		case MediaImage:
+			images++
+		case MediaGIF:
+			others++
+			limit = MaxGIFSize
+		case MediaVideo:
+			others++
+			limit = MaxVideoSize
+		}
+
+		if info.Size() > limit {
+			return fmt.Errorf("media %s is %d bytes, the limit for a %s is %d bytes", m.Path, info.Size(), kind, limit)
+		}
+
+		if len([]rune(m.Alt)) > MaxAltTextSize {
+			return fmt.Errorf("alt text for %s is longer than %d characters", m.Path, MaxAltTextSize)
+		}
+	}
+
+	switch {
+	case others > 1:
+		return fmt.Errorf("only one GIF or video can be attached to a tweet")
+	case others == 1 && images > 0:
+		return fmt.Errorf("a GIF or video cannot be combined with images")
+	case images > MaxImages:
+		return fmt.Errorf("at most %d images can be attached to a tweet, got %d", MaxImages, images)
+	}
+
+	return nil
+}
+
+// attachMedia returns the actions that upload the media through the compose
+// file input found under scope, fill in the alt text of each file and wait
+// until Twitter has finished processing the uploads.
+func attachMedia(scope string, media []Media) chromedp.Tasks {
+	if len(media) == 0 {
+		return nil
+	}
+
+	fileInputSelector := scope + `//input[@data-testid="fileInput"]`
+	attachmentsSelector := scope + `//div[@data-testid="attachments"]`
+	altTextInputSelector := `//div[@role="dialog"]//textarea[@data-testid="altTextInput"]`
+	altTextSaveSelector := `//div[@role="dialog"]//div[@data-testid="endEditingButton"]`
+
+	paths := make([]string, len(media))
+	for i, m := range media {
+		paths[i], _ = filepath.Abs(m.Path)
+	}
+
+	tasks := chromedp.Tasks{
+		chromedp.SetUploadFiles(fileInputSelector, paths, chromedp.BySearch),
+		chromedp.WaitVisible(attachmentsSelector, chromedp.BySearch),
+
+		// Wait until every upload has left the progress state.
+		chromedp.Poll(`!document.querySelector('[data-testid="attachments"] [role="progressbar"]')`, nil,
+			chromedp.WithPollingInterval(500*time.Millisecond),
+			chromedp.WithPollingTimeout(5*time.Minute),
+		),
+	}
+
+	for i, m := range media {
+		if m.Alt == "" {
+			continue
+		}
+
+		altTextButtonSelector := fmt.Sprintf(`(%s//a[@data-testid="altTextLabel"])[%d]`, attachmentsSelector, i+1)
+
+		tasks = append(tasks,
+			chromedp.Click(altTextButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.WaitVisible(altTextInputSelector, chromedp.BySearch),
+			chromedp.SendKeys(altTextInputSelector, m.Alt, chromedp.BySearch),
+			chromedp.Click(altTextSaveSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.WaitNotPresent(altTextInputSelector, chromedp.BySearch),
+		)
+	}
+
+	return tasks
+}
@@ -0,0 +1,85 @@
+package tweethub
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateMedia(t *testing.T) {
+	dir := t.TempDir()
+
+	// file creates a sparse file of the given size in dir.
+	file := func(name string, size int64) string {
+		path := filepath.Join(dir, name)
+
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		if err := f.Truncate(size); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	photo := file("photo.JPG", 1<<20)
+	bigPhoto := file("big.png", MaxImageSize+1)
+	gif := file("anim.gif", MaxImageSize+1)
+	bigGIF := file("big.gif", MaxGIFSize+1)
+	video := file("clip.mp4", 100<<20)
+	text := file("notes.txt", 10)
+
+	if err := os.Mkdir(filepath.Join(dir, "folder.png"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	media := func(paths ...string) []Media {
+		var list []Media
+		for _, path := range paths {
+			list = append(list, Media{Path: path})
+		}
+		return list
+	}
+
+	tests := []struct {
+		name    string
+		media   []Media
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "four images", media: media(photo, photo, photo, photo)},
+		{name: "gif above the image limit", media: media(gif)},
+		{name: "video", media: media(video)},
+		{name: "alt text", media: []Media{{Path: photo, Alt: strings.Repeat("é", MaxAltTextSize)}}},
+		{name: "five images", media: media(photo, photo, photo, photo, photo), wantErr: "at most 4 images"},
+		{name: "two videos", media: media(video, gif), wantErr: "only one GIF or video"},
+		{name: "video and image", media: media(photo, video), wantErr: "cannot be combined with images"},
+		{name: "image too big", media: media(bigPhoto), wantErr: "the limit for a image"},
+		{name: "gif too big", media: media(bigGIF), wantErr: "the limit for a gif"},
+		{name: "unsupported type", media: media(text), wantErr: `unsupported media type ".txt"`},
+		{name: "missing file", media: media(filepath.Join(dir, "missing.png")), wantErr: "cannot read media"},
+		{name: "directory", media: media(filepath.Join(dir, "folder.png")), wantErr: "is a directory"},
+		{name: "alt text too long", media: []Media{{Path: photo, Alt: strings.Repeat("a", MaxAltTextSize+1)}}, wantErr: "alt text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMedia(tt.media)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateMedia() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateMedia() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package tweethub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// MuteDuration is how long a word stays muted.
+type MuteDuration string
+
+const (
+	MuteForever MuteDuration = "forever"
+	Mute24Hours MuteDuration = "24h"
+	Mute7Days   MuteDuration = "7d"
+	Mute30Days  MuteDuration = "30d"
+)
+
+// muteDurationLabels maps each mute duration to its option on the settings page.
+var muteDurationLabels = map[MuteDuration]string{
+	MuteForever: "Forever",
+	Mute24Hours: "24 hours from now",
+	Mute7Days:   "7 days from now",
+	Mute30Days:  "30 days from now",
+}
+
+// MutedWord is an entry of the muted words settings of the account.
+type MutedWord struct {
+	Word   string `json:"word"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// MutedWordOptions controls where and for how long a word is muted.
+type MutedWordOptions struct {
+	Duration      MuteDuration
+	HomeTimeline  bool
+	Notifications bool
+}
+
+// ParseMuteDuration parses one of "forever", "24h", "7d" or "30d".
+func ParseMuteDuration(s string) (MuteDuration, error) {
+	duration := MuteDuration(s)
+
+	if _, ok := muteDurationLabels[duration]; !ok {
+		return "", fmt.Errorf("invalid mute duration %q, expected forever, 24h, 7d or 30d", s)
+	}
+
+	return duration, nil
+}
+
+// scrapeMutedWordsJS collects the entries of the muted words settings page.
+const scrapeMutedWordsJS = `Array.from(document.querySelectorAll('a[href*="/settings/muted_keywords/"]')).map(entry => {
+	const lines = entry.innerText.split('\n');
+
+	return {
+		word: lines[0],
+		detail: lines.slice(1).join(' '),
+	};
+})`
+
+// MutedWords returns the words muted by the account.
+func (s *Session) MutedWords() ([]MutedWord, error) {
+	mutedWordsURL := twitterURL + "/settings/muted_keywords"
+
+	if err := s.ready(); err != nil {
+		return nil, err
+	}
+
+	var words []MutedWord
+
+	err := scroll(s.ctx, mutedWordsURL, `a[href*="/settings/muted_keywords/"]`, scrapeMutedWordsJS, func(word MutedWord) string {
+		return strings.ToLower(word.Word)
+	}, 0, func(word MutedWord) bool {
+		words = append(words, word)
+		return true
+	})
+
+	return words, err
+}
+
+// Muted word form selectors, shared by the add and the edit pages.
+const (
+	keywordInputSelector  = `//input[@name="keyword"]`
+	homeTimelineSelector  = `//label[.//span[text()="Home timeline"]]//input[@type="checkbox"]`
+	notificationsSelector = `//label[.//span[text()="Notifications"]]//input[@type="checkbox"]`
+	saveMutedWordSelector = `//div[@data-testid="settingsDetailSave"]`
+)
+
+// AddMutedWords mutes words with opts, loading the muted words once. Words
+// already muted have their options updated when they differ from opts. fn is
+// called for each word with "muted", "updated" or "" when nothing changed,
+// and the error of the change, if any. In dry-run mode every word is planned
+// without loading the muted words.
+func (s *Session) AddMutedWords(words []string, opts MutedWordOptions, fn func(word, result string, err error)) error {
+	current, err := s.currentMutedWords()
+	if err != nil {
+		return err
+	}
+
+	s.addMutedWords(indexMutedWords(current), words, opts, fn)
+
+	return nil
+}
+
+// currentMutedWords returns the words muted by the account, or none in
+// dry-run mode, where they are not read.
+func (s *Session) currentMutedWords() ([]MutedWord, error) {
+	if s.hub.dryRun {
+		return nil, nil
+	}
+
+	return s.MutedWords()
+}
+
+// addMutedWords mutes words like AddMutedWords, given the muted words by
+// lowercase word, which it keeps up to date.
+func (s *Session) addMutedWords(muted map[string]MutedWord, words []string, opts MutedWordOptions, fn func(word, result string, err error)) {
+	added := make(map[string]bool)
+
+	for _, word := range words {
+		if added[strings.ToLower(word)] {
+			fn(word, "", nil)
+			continue
+		}
+
+		if current, ok := muted[strings.ToLower(word)]; ok {
+			updated, err := s.updateMutedWord(current.Word, opts)
+			if updated {
+				fn(word, "updated", err)
+			} else {
+				s.recordChange("mute-word", word, false, err)
+				fn(word, "", err)
+			}
+			continue
+		}
+
+		muted[strings.ToLower(word)] = MutedWord{Word: word}
+		added[strings.ToLower(word)] = true
+		fn(word, "muted", s.addMutedWord(word, opts))
+	}
+}
+
+// RemoveMutedWords unmutes words, loading the muted words once. fn is called
+// for each word with "unmuted", or "" when the word was not muted, and the
+// error of the change, if any. In dry-run mode every word is planned without
+// loading the muted words.
+func (s *Session) RemoveMutedWords(words []string, fn func(word, result string, err error)) error {
+	current, err := s.currentMutedWords()
+	if err != nil {
+		return err
+	}
+
+	muted := indexMutedWords(current)
+	if s.hub.dryRun {
+		for _, word := range words {
+			muted[strings.ToLower(word)] = MutedWord{Word: word}
+		}
+	}
+
+	for _, word := range words {
+		current, ok := muted[strings.ToLower(word)]
+		if !ok {
+			s.recordChange("unmute-word", word, false, nil)
+			fn(word, "", nil)
+			continue
+		}
+
+		delete(muted, strings.ToLower(word))
+		fn(word, "unmuted", s.removeMutedWord(current.Word))
+	}
+
+	return nil
+}
+
+// addMutedWord fills in the add muted word form.
+func (s *Session) addMutedWord(word string, opts MutedWordOptions) error {
+	if s.hub.planned("mute word", fmt.Sprintf("%q", word), opts.details()...) {
+		return nil
+	}
+
+	addMutedWordURL := twitterURL + "/settings/add_muted_keyword"
+
+	actions := []chromedp.Action{
+		chromedp.Navigate(addMutedWordURL),
+
+		chromedp.WaitVisible(keywordInputSelector, chromedp.BySearch),
+		chromedp.SendKeys(keywordInputSelector, word, chromedp.BySearch),
+	}
+	actions = append(actions, opts.fill()...)
+	actions = append(actions, chromedp.WaitNotPresent(keywordInputSelector, chromedp.BySearch))
+
+	err := s.run(actions...)
+
+	s.hub.record(ActionRecord{Action: "mute-word", Target: word}, err)
+
+	return err
+}
+
+// updateMutedWord opens the settings page of the muted word and saves opts
+// on it. It reports false without saving when the options already match.
+func (s *Session) updateMutedWord(word string, opts MutedWordOptions) (bool, error) {
+	var home, notifications, duration bool
+
+	err := s.run(
+		chromedp.Navigate(twitterURL+"/settings/muted_keywords"),
+
+		chromedp.WaitVisible(mutedWordEntrySelector(word), chromedp.BySearch),
+		chromedp.Click(mutedWordEntrySelector(word), chromedp.BySearch, chromedp.NodeVisible),
+
+		chromedp.WaitVisible(homeTimelineSelector, chromedp.BySearch),
+		chromedp.JavascriptAttribute(homeTimelineSelector, "checked", &home, chromedp.BySearch),
+		chromedp.JavascriptAttribute(notificationsSelector, "checked", &notifications, chromedp.BySearch),
+		chromedp.JavascriptAttribute(opts.durationSelector(), "checked", &duration, chromedp.BySearch),
+	)
+	if err != nil || (home == opts.HomeTimeline && notifications == opts.Notifications && duration) {
+		return false, err
+	}
+
+	if s.hub.planned("update muted word", fmt.Sprintf("%q", word), opts.details()...) {
+		return true, nil
+	}
+
+	actions := append(opts.fill(), chromedp.WaitNotPresent(homeTimelineSelector, chromedp.BySearch))
+
+	err = s.run(actions...)
+
+	s.hub.record(ActionRecord{Action: "update-muted-word", Target: word}, err)
+
+	return true, err
+}
+
+// removeMutedWord deletes the muted word entry from the settings page.
+func (s *Session) removeMutedWord(word string) error {
+	if s.hub.planned("unmute word", fmt.Sprintf("%q", word)) {
+		return nil
+	}
+
+	mutedWordsURL := twitterURL + "/settings/muted_keywords"
+
+	entrySelector := mutedWordEntrySelector(word)
+	deleteButtonSelector := `//div[@role="button"][.//span[text()="Delete word"]]`
+	confirmSelector := `//div[@data-testid="confirmationSheetConfirm"]`
+
+	err := s.run(
+		chromedp.Navigate(mutedWordsURL),
+
+		chromedp.WaitVisible(entrySelector, chromedp.BySearch),
+		chromedp.Click(entrySelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(deleteButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(confirmSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+		chromedp.WaitNotPresent(deleteButtonSelector, chromedp.BySearch),
+	)
+
+	s.hub.record(ActionRecord{Action: "unmute-word", Target: word}, err)
+
+	return err
+}
+
+// SyncMutedWords makes the muted words of the account match words: missing
+// words are muted with opts and muted ones get opts when theirs differ.
+// Words not in the list are unmuted with prune, and kept otherwise. fn is
+// called for each word with "muted", "updated", "unmuted", "kept" or "" when
+// nothing changed, and the error of the change, if any. In dry-run mode the
+// words are planned without loading the muted words, and so is the pruning.
+func (s *Session) SyncMutedWords(words []string, opts MutedWordOptions, prune bool, fn func(word, result string, err error)) error {
+	current, err := s.currentMutedWords()
+	if err != nil {
+		return err
+	}
+
+	s.addMutedWords(indexMutedWords(current), words, opts, fn)
+
+	if prune {
+		s.hub.planned("unmute words", "not in the list")
+	}
+
+	wanted := make(map[string]bool)
+	for _, word := range words {
+		wanted[strings.ToLower(word)] = true
+	}
+
+	for _, w := range current {
+		switch {
+		case wanted[strings.ToLower(w.Word)]:
+		case !prune:
+			fn(w.Word, "kept", nil)
+		default:
+			fn(w.Word, "unmuted", s.removeMutedWord(w.Word))
+		}
+	}
+
+	return nil
+}
+
+// indexMutedWords returns words by lowercase word.
+func indexMutedWords(words []MutedWord) map[string]MutedWord {
+	muted := make(map[string]MutedWord)
+	for _, w := range words {
+		muted[strings.ToLower(w.Word)] = w
+	}
+
+	return muted
+}
+
+// mutedWordEntrySelector matches the entry of word on the muted words page.
+func mutedWordEntrySelector(word string) string {
+	return fmt.Sprintf(`//a[contains(@href, "/settings/muted_keywords/")][.//span[text()=%s]]`, xpathLiteral(word))
+}
+
+// durationSelector matches the radio button of the duration of opts.
+func (opts MutedWordOptions) durationSelector() string {
+	return fmt.Sprintf(`//label[.//span[text()="%s"]]//input[@type="radio"]`, muteDurationLabels[opts.Duration])
+}
+
+// fill returns the actions setting opts on a muted word form and saving it.
+func (opts MutedWordOptions) fill() []chromedp.Action {
+	return []chromedp.Action{
+		setCheckbox(homeTimelineSelector, opts.HomeTimeline),
+		setCheckbox(notificationsSelector, opts.Notifications),
+		chromedp.Click(opts.durationSelector(), chromedp.BySearch),
+		chromedp.Click(saveMutedWordSelector, chromedp.BySearch, chromedp.NodeVisible),
+	}
+}
+
+// details describes opts for the dry-run plan.
+func (opts MutedWordOptions) details() []string {
+	var scopes []string
+	if opts.HomeTimeline {
+		scopes = append(scopes, "home timeline")
+	}
+	if opts.Notifications {
+		scopes = append(scopes, "notifications")
+	}
+
+	return []string{fmt.Sprintf("for %s in %s", opts.Duration, strings.Join(scopes, " and "))}
+}
+
+// setCheckbox clicks the checkbox matching selector if its state differs from checked.
+func setCheckbox(selector string, checked bool) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		var current bool
+
+		if err := chromedp.JavascriptAttribute(selector, "checked", &current, chromedp.BySearch).Do(ctx); err != nil {
+			return err
+		}
+
+		if current == checked {
+			return nil
+		}
+
+		return chromedp.Click(selector, chromedp.BySearch).Do(ctx)
+	})
+}
+
+// xpathLiteral quotes s as an XPath string literal.
+func xpathLiteral(s string) string {
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+
+	return `concat("` + strings.ReplaceAll(s, `"`, `", '"', "`) + `")`
+}
@@ -0,0 +1,127 @@
+package tweethub
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Limits applied to polls before they are created.
+const (
+	MinPollOptions      = 2
+	MaxPollOptions      = 4
+	MaxPollOptionLength = 25
+	MinPollDuration     = 5 * time.Minute
+	MaxPollDuration     = 7 * 24 * time.Hour
+)
+
+// Poll represents a poll attached to a tweet.
+type Poll struct {
+	Options  []string
+	Duration time.Duration
+}
+
+// ParsePollDuration parses a poll duration such as "1d", "6h", "1d12h" or "30m".
+// In addition to the units understood by time.ParseDuration, "d" stands for a day.
+func ParsePollDuration(s string) (time.Duration, error) {
+	var days int
+
+	if i := strings.Index(s, "d"); i >= 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid poll duration %q", s)
+		}
+
+		days = n
+		s = s[i+1:]
+	}
+
+	var rest time.Duration
+
+	if s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid poll duration %q", s)
+		}
+
+		rest = d
+	}
+
+	return time.Duration(days)*24*time.Hour + rest, nil
+}
+
+// Validate checks the poll against the limits enforced by Twitter.
+func (p Poll) Validate() error {
+	if len(p.Options) < MinPollOptions || len(p.Options) > MaxPollOptions {
+		return fmt.Errorf("a poll needs between %d and %d options, got %d", MinPollOptions, MaxPollOptions, len(p.Options))
+	}
+
+	for _, option := range p.Options {
+		length := len([]rune(option))
+
+		if length == 0 {
+			return fmt.Errorf("poll options cannot be empty")
+		}
+
+		if length > MaxPollOptionLength {
+			return fmt.Errorf("poll option %q is longer than %d characters", option, MaxPollOptionLength)
+		}
+	}
+
+	if p.Duration < MinPollDuration || p.Duration > MaxPollDuration {
+		return fmt.Errorf("poll duration must be between %v and %v, got %v", MinPollDuration, MaxPollDuration, p.Duration)
+	}
+
+	if p.Duration%time.Minute != 0 {
+		return fmt.Errorf("poll duration must be a whole number of minutes, got %v", p.Duration)
+	}
+
+	return nil
+}
+
+// createPoll returns the actions that open the compose poll UI, fill in the
+// options and select the poll duration.
+func createPoll(poll *Poll) chromedp.Tasks {
+	if poll == nil {
+		return nil
+	}
+
+	pollButtonSelector := `//main//div[@data-testid="createPollButton"]`
+	addChoiceSelector := `//main//div[@aria-label="Add a choice"]`
+	daysSelector := `//main//select[@data-testid="selectPollDays"]`
+	hoursSelector := `//main//select[@data-testid="selectPollHours"]`
+	minutesSelector := `//main//select[@data-testid="selectPollMinutes"]`
+
+	tasks := chromedp.Tasks{
+		chromedp.Click(pollButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+	}
+
+	for i, option := range poll.Options {
+		choiceSelector := fmt.Sprintf(`//main//input[@name="Choice%d"]`, i+1)
+
+		// The poll UI starts with two choices, further ones have to be added.
+		if i >= MinPollOptions {
+			tasks = append(tasks, chromedp.Click(addChoiceSelector, chromedp.BySearch, chromedp.NodeVisible))
+		}
+
+		tasks = append(tasks,
+			chromedp.WaitVisible(choiceSelector, chromedp.BySearch),
+			chromedp.SendKeys(choiceSelector, option, chromedp.BySearch),
+		)
+	}
+
+	days := int(poll.Duration / (24 * time.Hour))
+	hours := int(poll.Duration % (24 * time.Hour) / time.Hour)
+	minutes := int(poll.Duration % time.Hour / time.Minute)
+
+	tasks = append(tasks,
+		chromedp.SendKeys(daysSelector, strconv.Itoa(days), chromedp.BySearch),
+		chromedp.SendKeys(hoursSelector, strconv.Itoa(hours), chromedp.BySearch),
+		chromedp.SendKeys(minutesSelector, strconv.Itoa(minutes), chromedp.BySearch),
+	)
+
+	return tasks
+}
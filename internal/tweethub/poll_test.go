@@ -0,0 +1,44 @@
+package tweethub
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePollDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "30m", want: 30 * time.Minute},
+		{in: "6h", want: 6 * time.Hour},
+		{in: "1d", want: 24 * time.Hour},
+		{in: "1d12h", want: 36 * time.Hour},
+		{in: "7d", want: 7 * 24 * time.Hour},
+		{in: "2h30m", want: 2*time.Hour + 30*time.Minute},
+		{in: "", want: 0},
+		{in: "d", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "1d1x", wantErr: true},
+		{in: "tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePollDuration(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParsePollDuration(%q) = %v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePollDuration(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePollDuration(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
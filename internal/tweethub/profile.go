@@ -0,0 +1,253 @@
+package tweethub
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/chromedp/cdproto/input"
+	"github.com/chromedp/chromedp"
+	"github.com/chromedp/chromedp/kb"
+)
+
+// Limits applied to profile fields before they are saved.
+const (
+	MaxNameLength     = 50
+	MaxBioLength      = 160
+	MaxLocationLength = 30
+	MaxWebsiteLength  = 100
+	MaxAvatarSize     = 2 << 20
+	MaxBannerSize     = 5 << 20
+)
+
+// Profile holds the editable fields of a Twitter profile.
+type Profile struct {
+	Name     string `json:"name"`
+	Bio      string `json:"bio"`
+	Location string `json:"location"`
+	Website  string `json:"website"`
+}
+
+// ProfileUpdate describes a change to a Twitter profile.
+// Nil fields and empty image paths are left unchanged.
+type ProfileUpdate struct {
+	Name     *string
+	Bio      *string
+	Location *string
+	Website  *string
+	Avatar   string
+	Banner   string
+}
+
+// readProfileJS reads the current values of the edit profile form.
+const readProfileJS = `(() => {
+	const value = selector => (document.querySelector(selector) || {}).value || "";
+
+	return {
+		name: value('input[name="displayName"]'),
+		bio: value('textarea[name="description"]'),
+		location: value('input[name="location"]'),
+		website: value('input[name="url"]'),
+	};
+})()`
+
+// Validate checks the update against the limits enforced by Twitter.
+func (u ProfileUpdate) Validate() error {
+	fields := []struct {
+		name  string
+		value *string
+		limit int
+	}{
+		{"name", u.Name, MaxNameLength},
+		{"bio", u.Bio, MaxBioLength},
+		{"location", u.Location, MaxLocationLength},
+		{"website", u.Website, MaxWebsiteLength},
+	}
+
+	for _, field := range fields {
+		if field.value != nil && len([]rune(*field.value)) > field.limit {
+			return fmt.Errorf("%s is longer than %d characters", field.name, field.limit)
+		}
+	}
+
+	if u.Name != nil && *u.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	images := []struct {
+		name  string
+		path  string
+		limit int64
+	}{
+		{"avatar", u.Avatar, MaxAvatarSize},
+		{"banner", u.Banner, MaxBannerSize},
+	}
+
+	for _, image := range images {
+		if image.path == "" {
+			continue
+		}
+
+		kind, err := Media{Path: image.path}.Kind()
+		if err != nil {
+			return err
+		}
+
+		if kind != MediaImage {
+			return fmt.Errorf("%s must be an image, got a %s", image.name, kind)
+		}
+
+		info, err := os.Stat(image.path)
+		if err != nil {
+			return fmt.Errorf("cannot read %s %s: %v", image.name, image.path, err)
+		}
+
+		if info.Size() > image.limit {
+			return fmt.Errorf("%s %s is %d bytes, the limit is %d bytes", image.name, image.path, info.Size(), image.limit)
+		}
+	}
+
+	return nil
+}
+
+// Profile reads the current profile of the account from the edit profile form.
+func (t TweetHub) Profile() (Profile, context.CancelFunc, error) {
+	profileSettingsURL := twitterURL + "/settings/profile"
+
+	nameInputSelector := `//div[@role="dialog"]//input[@name="displayName"]`
+
+	var profile Profile
+
+	ctx, cancel := t.Login()
+
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(profileSettingsURL),
+
+		chromedp.WaitVisible(nameInputSelector, chromedp.BySearch),
+		chromedp.Evaluate(readProfileJS, &profile),
+	)
+
+	return profile, cancel, err
+}
+
+// UpdateProfile changes the profile of the account through the edit profile dialog.
+func (t TweetHub) UpdateProfile(update ProfileUpdate) context.CancelFunc {
+	if t.planned("update profile", "", update.details()...) {
+		return func() {}
+	}
+
+	profileSettingsURL := twitterURL + "/settings/profile"
+
+	nameInputSelector := `//div[@role="dialog"]//input[@name="displayName"]`
+	bannerInputSelector := `(//div[@role="dialog"]//input[@data-testid="fileInput"])[1]`
+	avatarInputSelector := `(//div[@role="dialog"]//input[@data-testid="fileInput"])[2]`
+	applyButtonSelector := `//div[@role="dialog"]//div[@data-testid="applyButton"]`
+	saveButtonSelector := `//div[@role="dialog"]//div[@data-testid="Profile_Save_Button"]`
+
+	tasks := chromedp.Tasks{
+		chromedp.Navigate(profileSettingsURL),
+		chromedp.WaitVisible(nameInputSelector, chromedp.BySearch),
+	}
+
+	images := []struct {
+		selector string
+		path     string
+	}{
+		{bannerInputSelector, update.Banner},
+		{avatarInputSelector, update.Avatar},
+	}
+
+	for _, image := range images {
+		if image.path == "" {
+			continue
+		}
+
+		path, _ := filepath.Abs(image.path)
+
+		tasks = append(tasks,
+			chromedp.SetUploadFiles(image.selector, []string{path}, chromedp.BySearch),
+			chromedp.Click(applyButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+			chromedp.WaitNotPresent(applyButtonSelector, chromedp.BySearch),
+		)
+	}
+
+	fields := []struct {
+		selector string
+		value    *string
+	}{
+		{nameInputSelector, update.Name},
+		{`//div[@role="dialog"]//textarea[@name="description"]`, update.Bio},
+		{`//div[@role="dialog"]//input[@name="location"]`, update.Location},
+		{`//div[@role="dialog"]//input[@name="url"]`, update.Website},
+	}
+
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+
+		tasks = append(tasks, replaceText(field.selector, *field.value))
+	}
+
+	tasks = append(tasks,
+		chromedp.Click(saveButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.WaitNotPresent(saveButtonSelector, chromedp.BySearch),
+	)
+
+	ctx, cancel := t.Login()
+
+	err := chromedp.Run(ctx, tasks)
+
+	t.record(ActionRecord{Action: "update-profile", Target: t.username}, err)
+
+	if err != nil {
+		fmt.Printf("Failed to update profile of @%s: %v\n", t.username, err)
+	} else {
+		fmt.Printf("Updated profile of @%s successfully\n", t.username)
+	}
+
+	return cancel
+}
+
+// replaceText replaces the content of a text field the way a user would, so
+// that the web interface notices the change.
+func replaceText(selector, value string) chromedp.Tasks {
+	return chromedp.Tasks{
+		chromedp.Click(selector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.KeyEvent("a", chromedp.KeyModifiers(input.ModifierCtrl)),
+		chromedp.KeyEvent(kb.Backspace),
+		chromedp.SendKeys(selector, value, chromedp.BySearch),
+	}
+}
+
+// details describes the changes of the update.
+func (u ProfileUpdate) details() []string {
+	var details []string
+
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"name", u.Name},
+		{"bio", u.Bio},
+		{"location", u.Location},
+		{"website", u.Website},
+	}
+
+	for _, field := range fields {
+		if field.value != nil {
+			details = append(details, fmt.Sprintf("%s: %q", field.name, *field.value))
+		}
+	}
+
+	if u.Avatar != "" {
+		details = append(details, "avatar: "+u.Avatar)
+	}
+
+	if u.Banner != "" {
+		details = append(details, "banner: "+u.Banner)
+	}
+
+	return details
+}
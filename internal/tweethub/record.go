@@ -0,0 +1,64 @@
+package tweethub
+
+// Outcomes of an action.
+const (
+	OutcomeSuccess = "success"
+	OutcomeSkipped = "skipped"
+	OutcomeFailed  = "failed"
+)
+
+// ActionRecord describes an action run on Twitter, such as a like or a new
+// tweet, once it has finished.
+type ActionRecord struct {
+	Account string
+	Action  string
+	Target  string
+	Message string
+	Outcome string
+	URL     string
+	Err     error
+}
+
+// SetRecorder sets a function called with the record of every action run by
+// the TweetHub instance and the sessions it starts, whether it failed or not.
+func (t *TweetHub) SetRecorder(fn func(ActionRecord)) {
+	t.recorder = fn
+}
+
+// record completes r with the account and the outcome of err and passes it to
+// the recorder, if any. An unset outcome without error means success.
+func (t TweetHub) record(r ActionRecord, err error) {
+	if t.recorder == nil {
+		return
+	}
+
+	r.Account = t.username
+	r.Err = err
+
+	switch {
+	case err != nil:
+		r.Outcome = OutcomeFailed
+	case r.Outcome == "":
+		r.Outcome = OutcomeSuccess
+	}
+
+	t.recorder(r)
+}
+
+// recordChange records an action of the session that leaves things as they
+// are when changed is false, and returns changed and err. Nothing is recorded
+// in dry-run mode, where the action was only planned.
+func (s *Session) recordChange(action, target string, changed bool, err error) (bool, error) {
+	if s.hub.dryRun {
+		return changed, err
+	}
+
+	r := ActionRecord{Action: action, Target: target}
+	if !changed {
+		r.Outcome = OutcomeSkipped
+	}
+
+	s.hub.record(r, err)
+
+	return changed, err
+}
@@ -0,0 +1,208 @@
+package tweethub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Post represents a tweet scraped from a page of the Twitter web interface.
+type Post struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	Media     []string  `json:"media,omitempty"`
+	Replies   int       `json:"replies"`
+	Reposts   int       `json:"reposts"`
+	Likes     int       `json:"likes"`
+	Views     int       `json:"views"`
+	Pinned    bool      `json:"pinned,omitempty"`
+	Repost    bool      `json:"repost,omitempty"`
+	Quoted    *Post     `json:"quoted,omitempty"`
+	Parent    *Post     `json:"parent,omitempty"`
+}
+
+// scrapePostsJS collects the tweets currently rendered on the page.
+// Counts are read from the aria-label of the action buttons, e.g. "12 Likes. Like".
+// A quoted tweet is rendered inside the article, in a div with the link role,
+// so the fields of the tweet are read outside of it and those of the quoted
+// tweet inside of it.
+const scrapePostsJS = `Array.from(document.querySelectorAll('article[data-testid="tweet"]')).map(article => {
+	const all = (root, selector) => Array.from(root.querySelectorAll(selector));
+	const quotedName = article.querySelector('div[role="link"] [data-testid="User-Name"]');
+	const quoted = quotedName ? quotedName.closest('div[role="link"]') : null;
+	const outer = element => !quoted || !quoted.contains(element);
+
+	const time = all(article, 'time').find(outer);
+	const link = time ? time.closest('a') : null;
+	const texts = all(article, '[data-testid="tweetText"]').filter(outer);
+	const authors = all(article, '[data-testid="User-Name"] a[href^="/"]').filter(outer);
+	const count = selector => {
+		const button = article.querySelector(selector);
+		const label = button ? button.getAttribute('aria-label') || "" : "";
+		const match = label.match(/^[\d,]+/);
+		return match ? parseInt(match[0].replace(/,/g, ''), 10) : 0;
+	};
+	const context = all(article, '[data-testid="socialContext"]').map(e => e.innerText).join(' ');
+	const media = (root, keep) => all(root, '[data-testid="tweetPhoto"] img, video')
+		.filter(keep)
+		.map(media => media.src || media.poster)
+		.filter(Boolean);
+
+	const quotedTime = quoted ? quoted.querySelector('time') : null;
+	const quotedText = quoted ? quoted.querySelector('[data-testid="tweetText"]') : null;
+	const quotedLink = quoted ? all(quoted, 'a[href*="/status/"]').map(a => a.href.match(/^.*\/status\/\d+/)).find(Boolean) : null;
+
+	return {
+		url: link ? link.href : "",
+		author: authors.length ? authors[0].getAttribute('href').slice(1) : "",
+		text: texts.length ? texts[0].innerText : "",
+		created_at: time ? time.getAttribute('datetime') : null,
+		media: media(article, outer),
+		replies: count('[data-testid="reply"]'),
+		reposts: count('[data-testid="retweet"], [data-testid="unretweet"]'),
+		likes: count('[data-testid="like"], [data-testid="unlike"]'),
+		views: count('a[href$="/analytics"]'),
+		pinned: /Pinned/.test(context),
+		repost: /reposted/i.test(context),
+		quoted: quoted ? {
+			url: quotedLink ? quotedLink[0] : "",
+			author: ((quotedName.innerText.match(/@(\w+)/) || [])[1]) || "",
+			text: quotedText ? quotedText.innerText : "",
+			created_at: quotedTime ? quotedTime.getAttribute('datetime') : null,
+			media: media(quoted, () => true),
+		} : null,
+	};
+})`
+
+// User represents an account listed on a page of the Twitter web interface.
+type User struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// scrapeUsersJS collects the user cells currently rendered on the page.
+const scrapeUsersJS = `Array.from(document.querySelectorAll('[data-testid="UserCell"]')).map(cell => {
+	const link = cell.querySelector('a[href^="/"]');
+	const name = link ? link.innerText.split('\n')[0] : "";
+
+	return {
+		username: link ? link.getAttribute('href').slice(1) : "",
+		name: name,
+	};
+})`
+
+// maxIdleScrolls is the number of scrolls without new items after which a
+// page is considered fully loaded.
+const maxIdleScrolls = 5
+
+// emptyPageTimeout is how long a page may take to show its first item before
+// it is considered empty.
+const emptyPageTimeout = 15 * time.Second
+
+// scrollTimeline navigates to pageURL and scrolls down the timeline, calling fn
+// once for every tweet found, deduplicated by status ID. It stops when fn
+// returns false, when limit tweets have been seen (zero means no limit) or
+// when scrolling no longer loads new tweets.
+func scrollTimeline(ctx context.Context, pageURL string, limit int, fn func(Post) bool) error {
+	return scroll(ctx, pageURL, `article[data-testid="tweet"]`, scrapePostsJS, postID, limit, func(post Post) bool {
+		setIDs(&post)
+		return fn(post)
+	})
+}
+
+// scrollUsers navigates to pageURL and scrolls down the list of users, calling
+// fn once for every user found. It stops like scrollTimeline.
+func scrollUsers(ctx context.Context, pageURL string, limit int, fn func(User) bool) error {
+	return scroll(ctx, pageURL, `[data-testid="UserCell"]`, scrapeUsersJS, func(user User) string {
+		return user.Username
+	}, limit, fn)
+}
+
+// postID returns the status ID of post, or an empty string if its URL has none.
+func postID(post Post) string {
+	ref, err := ParseTweetRef(post.URL)
+	if err != nil {
+		return ""
+	}
+
+	return ref.ID
+}
+
+// setIDs sets the status IDs of post and of the post it quotes from their URLs.
+func setIDs(post *Post) {
+	post.ID = postID(*post)
+
+	if post.Quoted != nil {
+		post.Quoted.ID = postID(*post.Quoted)
+	}
+}
+
+// scroll navigates to pageURL, waits for an element matching the CSS
+// itemSelector and then repeatedly scrapes the page with scrapeJS and scrolls
+// down. Each item is passed to fn once, deduplicated by key; items with an
+// empty key are skipped. A page without items is not an error.
+func scroll[T any](ctx context.Context, pageURL, itemSelector, scrapeJS string, key func(T) string, limit int, fn func(T) bool) error {
+	primaryColumnSelector := `//div[@data-testid="primaryColumn"]`
+
+	loadCtx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+
+	err := chromedp.Run(loadCtx,
+		chromedp.Navigate(pageURL),
+		chromedp.WaitVisible(primaryColumnSelector, chromedp.BySearch),
+		chromedp.Poll(fmt.Sprintf(`!!document.querySelector('%s')`, itemSelector), nil,
+			chromedp.WithPollingTimeout(emptyPageTimeout),
+		),
+	)
+	if errors.Is(err, chromedp.ErrPollingTimeout) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+
+	for idle := 0; idle < maxIdleScrolls; {
+		var items []T
+
+		err := chromedp.Run(ctx,
+			chromedp.Evaluate(scrapeJS, &items),
+		)
+		if err != nil {
+			return err
+		}
+
+		idle++
+
+		for _, item := range items {
+			k := key(item)
+			if k == "" || seen[k] {
+				continue
+			}
+
+			seen[k] = true
+			idle = 0
+
+			if !fn(item) || (limit > 0 && len(seen) >= limit) {
+				return nil
+			}
+		}
+
+		err = chromedp.Run(ctx,
+			chromedp.Evaluate(`window.scrollBy(0, window.innerHeight)`, nil),
+			chromedp.Sleep(1500*time.Millisecond),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
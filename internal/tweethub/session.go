@@ -0,0 +1,66 @@
+package tweethub
+
+import (
+	"context"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Session is a logged-in browser session that runs several actions in a row
+// without logging in again for each one. Every action gets its own timeout,
+// so a session can last as long as needed.
+type Session struct {
+	ctx     context.Context
+	hub     TweetHub
+	started bool
+}
+
+// NewSession starts a browser, logs in and returns the Session along with
+// its cancel function, which must be called to close the browser. In dry-run
+// mode the browser is only started when the session first reads something.
+func (t TweetHub) NewSession() (*Session, context.CancelFunc, error) {
+	ctx, cancel := chromeContext(0)
+	s := &Session{ctx: ctx, hub: t}
+
+	if t.dryRun {
+		return s, cancel, nil
+	}
+
+	return s, cancel, s.ready()
+}
+
+// ready starts the browser and logs in, unless it was already done.
+func (s *Session) ready() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+
+	// Start the browser on the session context, so that it outlives the
+	// timeout of the first action.
+	if err := chromedp.Run(s.ctx); err != nil {
+		return err
+	}
+
+	loginCtx, cancelLogin := context.WithTimeout(s.ctx, actionTimeout)
+	defer cancelLogin()
+
+	return s.hub.login(loginCtx)
+}
+
+// Username returns the username of the account the session is logged in as.
+func (s *Session) Username() string {
+	return s.hub.username
+}
+
+// run runs actions in the session browser within actionTimeout.
+func (s *Session) run(actions ...chromedp.Action) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, actionTimeout)
+	defer cancel()
+
+	return chromedp.Run(ctx, actions...)
+}
@@ -0,0 +1,60 @@
+package tweethub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Show returns the tweet identified by its URL or status ID, read from its page.
+func (t TweetHub) Show(tweetRef string) (Post, context.CancelFunc, error) {
+	s, cancel, err := t.NewSession()
+	if err != nil {
+		return Post{}, cancel, err
+	}
+
+	post, err := s.Show(tweetRef)
+
+	return post, cancel, err
+}
+
+// Show returns the tweet identified by its URL or status ID, read from its
+// page. The tweet right above it in the thread, if any, is set as its parent.
+func (s *Session) Show(tweetRef string) (Post, error) {
+	ref, err := ParseTweetRef(tweetRef)
+	if err != nil {
+		return Post{}, err
+	}
+
+	tweetSelector := fmt.Sprintf(`//article[@data-testid="tweet"][.//a[contains(@href, "/status/%s")]]`, ref.ID)
+
+	var posts []Post
+
+	err = s.run(
+		chromedp.Navigate(ref.URL()),
+
+		chromedp.WaitVisible(tweetSelector, chromedp.BySearch),
+		chromedp.Evaluate(scrapePostsJS, &posts),
+	)
+	if err != nil {
+		return Post{}, err
+	}
+
+	for i, post := range posts {
+		setIDs(&post)
+		if post.ID != ref.ID {
+			continue
+		}
+
+		if i > 0 {
+			parent := posts[i-1]
+			setIDs(&parent)
+			post.Parent = &parent
+		}
+
+		return post, nil
+	}
+
+	return Post{}, fmt.Errorf("tweet %s not found on its page", ref.ID)
+}
@@ -0,0 +1,54 @@
+package tweethub
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Timeline scrolls the profile timeline of username, calling fn once for
+// every tweet authored by username, newest first apart from a pinned tweet,
+// which comes first. Reposts of other accounts are skipped. It stops when fn
+// returns false or the end of the timeline is reached.
+func (s *Session) Timeline(username string, fn func(Post) bool) error {
+	profileURL, _ := url.JoinPath(twitterURL, username)
+
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	return scrollTimeline(s.ctx, profileURL, 0, func(post Post) bool {
+		if !strings.EqualFold(post.Author, username) {
+			return true
+		}
+
+		return fn(post)
+	})
+}
+
+// Followers returns the accounts following username.
+func (s *Session) Followers(username string) ([]User, error) {
+	return s.users(username, "followers")
+}
+
+// Following returns the accounts username follows.
+func (s *Session) Following(username string) ([]User, error) {
+	return s.users(username, "following")
+}
+
+// users collects every user listed on the given tab of the profile of username.
+func (s *Session) users(username, tab string) ([]User, error) {
+	tabURL, _ := url.JoinPath(twitterURL, username, tab)
+
+	if err := s.ready(); err != nil {
+		return nil, err
+	}
+
+	var users []User
+
+	err := scrollUsers(s.ctx, tabURL, 0, func(user User) bool {
+		users = append(users, user)
+		return true
+	})
+
+	return users, err
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"time"
 
 	"github.com/chromedp/chromedp"
@@ -15,15 +16,29 @@ import (
 
 var twitterURL string = "https://twitter.com"
 
+// actionTimeout bounds the time a single action, including the login, may take.
+const actionTimeout = 120 * time.Second
+
 // TweetHub represents the main interface for interacting with Twitter.
 type TweetHub struct {
 	username string
 	password string
+	media    []Media
+	poll     *Poll
+
+	replySettings ReplySettings
+	sensitive     bool
+	scheduleAt    time.Time
+
+	dryRun       bool
+	invalidPlans *int
+	recorder     func(ActionRecord)
 }
 
 // chromeContext returns a new Chrome context and associated cancel function.
 // It is used for setting up the headless browser environment.
-func chromeContext() (context.Context, context.CancelFunc) {
+// A timeout of zero leaves the context without a deadline.
+func chromeContext(timeout time.Duration) (context.Context, context.CancelFunc) {
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
 		chromedp.Flag("headless", false),
 	)
@@ -34,7 +49,10 @@ func chromeContext() (context.Context, context.CancelFunc) {
 	ctx, cancelCtx := chromedp.NewContext(allocCtx)
 
 	// create a timeout
-	ctx, cancelTimeout := context.WithTimeout(ctx, 120*time.Second)
+	cancelTimeout := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+	}
 
 	cancel := func() {
 		cancelAlloc()
@@ -57,6 +75,11 @@ func (t *TweetHub) SetUsername(username string) {
 	}
 }
 
+// Username returns the username of the account.
+func (t TweetHub) Username() string {
+	return t.username
+}
+
 // SetPassword sets the Twitter password for the TweetHub instance.
 func (t *TweetHub) SetPassword(password string) {
 	if password != "" {
@@ -64,9 +87,28 @@ func (t *TweetHub) SetPassword(password string) {
 	}
 }
 
+// SetMedia sets the media attached to the tweets created by Tweet and Quote.
+func (t *TweetHub) SetMedia(media []Media) {
+	t.media = media
+}
+
+// SetPoll sets the poll attached to the tweets created by Tweet.
+func (t *TweetHub) SetPoll(poll *Poll) {
+	t.poll = poll
+}
+
 // Login performs the login to Twitter with the provided credentials.
 // It returns the Chrome context and associated cancel function for further interactions.
 func (t TweetHub) Login() (context.Context, context.CancelFunc) {
+	ctx, cancel := chromeContext(actionTimeout)
+
+	t.login(ctx)
+
+	return ctx, cancel
+}
+
+// login logs in to Twitter in the browser of ctx and reports the outcome.
+func (t TweetHub) login(ctx context.Context) error {
 	twitterLoginURL, _ := url.JoinPath(twitterURL, "login")
 
 	inputUsernameSelector := `//div/div/div/div/div/div/div[2]/div[2]/div/div/div[2]/div[2]/div/div/div/div[5]/label/div/div[2]/div/input[@autocomplete="username"]`
@@ -74,8 +116,6 @@ func (t TweetHub) Login() (context.Context, context.CancelFunc) {
 
 	cellInnerSelector := `//div/div/div[2]/main/div/div/div/div/div/div[5]/div/section/div/div/div[@data-testid="cellInnerDiv"]`
 
-	ctx, cancel := chromeContext()
-
 	err := chromedp.Run(ctx,
 		chromedp.Navigate(twitterLoginURL),
 
@@ -89,16 +129,20 @@ func (t TweetHub) Login() (context.Context, context.CancelFunc) {
 	)
 
 	if err != nil {
-		fmt.Printf("Failed to login for user %s: %v", t.username, err)
+		fmt.Fprintf(os.Stderr, "Failed to login for user %s: %v\n", t.username, err)
 	} else {
-		fmt.Printf("Successful login for user: %s\n", t.username)
+		fmt.Fprintf(os.Stderr, "Successful login for user: %s\n", t.username)
 	}
 
-	return ctx, cancel
+	return err
 }
 
 // Like performs the "like" action on a given tweet URL.
 func (t TweetHub) Like(tweetURL string) context.CancelFunc {
+	if t.plannedTweet("like", tweetURL) {
+		return func() {}
+	}
+
 	likeButtonSelector := `//div[3]/div[@data-testid="like"]`
 	unlikeButtonSelector := `//div[3]/div[@data-testid="unlike"]`
 
@@ -113,6 +157,8 @@ func (t TweetHub) Like(tweetURL string) context.CancelFunc {
 		chromedp.WaitVisible(unlikeButtonSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "like", Target: tweetURL}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to like the content at %s: %v", tweetURL, err)
 		return cancel
@@ -125,6 +171,10 @@ func (t TweetHub) Like(tweetURL string) context.CancelFunc {
 
 // UnLike performs the "unlike" action on a given tweet URL.
 func (t TweetHub) UnLike(tweetURL string) context.CancelFunc {
+	if t.plannedTweet("unlike", tweetURL) {
+		return func() {}
+	}
+
 	likeButtonSelector := `//div[3]/div[@data-testid="like"]`
 	unlikeButtonSelector := `//div[3]/div[@data-testid="unlike"]`
 
@@ -139,6 +189,8 @@ func (t TweetHub) UnLike(tweetURL string) context.CancelFunc {
 		chromedp.WaitVisible(likeButtonSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "unlike", Target: tweetURL}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to unlike the content at %s: %v", tweetURL, err)
 		return cancel
@@ -150,31 +202,57 @@ func (t TweetHub) UnLike(tweetURL string) context.CancelFunc {
 }
 
 // Tweet creates a new tweet with the provided message.
-func (t TweetHub) Tweet(message string) context.CancelFunc {
+// It returns the URL of the created tweet, or an empty string if it could not be determined.
+func (t TweetHub) Tweet(message string) (string, context.CancelFunc) {
+	if t.planned("tweet", "", t.composeDetails(message)...) {
+		return "", func() {}
+	}
+
 	tweetTextareaSelector := `//div/div/div[2]/main/div/div/div/div/div/div[3]/div/div[2]/div[1]/div/div/div/div[2]/div[1]/div/div/div/div/div/div/div/div/div/div/label/div[1]/div/div/div/div/div/div[2]/div[@data-testid="tweetTextarea_0"]`
+	tweetPostButtonSelector := `//div[@data-testid="tweetButtonInline"]`
 	alertSelector := `//div[2]/div/div/div/div[@role="alert"]`
 
+	var tweetURL string
+
 	ctx, cancel := t.Login()
 
 	err := chromedp.Run(ctx,
 		chromedp.SendKeys(tweetTextareaSelector, message),
-		chromedp.KeyEvent(kb.Tab+kb.Tab+kb.Tab+kb.Tab+kb.Tab+kb.Tab+kb.Tab+kb.Tab+kb.Enter),
+		attachMedia(`//main`, t.media),
+		createPoll(t.poll),
+		t.composeOptions(`//main`),
+		chromedp.Click(tweetPostButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
 
 		chromedp.WaitVisible(alertSelector, chromedp.BySearch),
+		createdTweetURL(&tweetURL),
 	)
 
+	t.record(ActionRecord{Action: "tweet", Message: message, URL: tweetURL}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to create tweet: %v\n", err)
-		return cancel
+		return "", cancel
+	} else if !t.scheduleAt.IsZero() {
+		fmt.Printf("Tweet scheduled for %s\n", t.scheduleAt.Format(time.RFC1123))
 	} else {
-		fmt.Println("Tweet created successfully")
+		fmt.Println("Tweet created successfully:", tweetURL)
 	}
 
-	return cancel
+	return tweetURL, cancel
+}
+
+// createdTweetURL reads the URL of the tweet just posted from the link in the
+// confirmation alert. It leaves tweetURL empty when the alert carries no link.
+func createdTweetURL(tweetURL *string) chromedp.Action {
+	return chromedp.Evaluate(`(document.querySelector('[role="alert"] a[href*="/status/"]') || {}).href || ""`, tweetURL)
 }
 
 // UnTweet deletes an existing tweet identified by its URL.
 func (t TweetHub) UnTweet(tweetURL string) context.CancelFunc {
+	if t.plannedTweet("untweet", tweetURL) {
+		return func() {}
+	}
+
 	moreSelector := `//div/div/div[2]/main/div/div/div/div/div/section/div/div/div[1]/div/div/article/div/div/div[2]/div[2]/div/div/div[2]/div/div/div/div/div[@aria-label="More"]`
 	alertSelector := `//div[2]/div/div/div/div[@role="alert"]`
 
@@ -191,6 +269,8 @@ func (t TweetHub) UnTweet(tweetURL string) context.CancelFunc {
 		chromedp.WaitVisible(alertSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "untweet", Target: tweetURL}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to delete tweet at URL %s: %v\n", tweetURL, err)
 		return cancel
@@ -203,6 +283,9 @@ func (t TweetHub) UnTweet(tweetURL string) context.CancelFunc {
 
 // Repost performs the "repost" action on a given post URL.
 func (t TweetHub) Repost(postURL string) context.CancelFunc {
+	if t.plannedTweet("repost", postURL) {
+		return func() {}
+	}
 
 	retweetButtonSelector := `//div[2]/div[@data-testid="retweet"]`
 	unretweetButtonSelector := `//div[2]/div[@data-testid="unretweet"]`
@@ -222,6 +305,8 @@ func (t TweetHub) Repost(postURL string) context.CancelFunc {
 		chromedp.WaitVisible(unretweetButtonSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "repost", Target: postURL}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to repost: %v\n", err)
 	} else {
@@ -233,6 +318,9 @@ func (t TweetHub) Repost(postURL string) context.CancelFunc {
 
 // UnRepost performs the "unrepost" action on a given post URL.
 func (t TweetHub) UnRepost(postURL string) context.CancelFunc {
+	if t.plannedTweet("unrepost", postURL) {
+		return func() {}
+	}
 
 	retweetButtonSelector := `//div[2]/div[@data-testid="retweet"]`
 	unretweetButtonSelector := `//div[2]/div[@data-testid="unretweet"]`
@@ -251,6 +339,8 @@ func (t TweetHub) UnRepost(postURL string) context.CancelFunc {
 		chromedp.WaitVisible(retweetButtonSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "unrepost", Target: postURL}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to unrepost: %v\n", err)
 		return cancel
@@ -263,6 +353,10 @@ func (t TweetHub) UnRepost(postURL string) context.CancelFunc {
 
 // Quote performs the "quote" action on a given post URL with an optional custom message.
 func (t TweetHub) Quote(postURL string, message ...string) context.CancelFunc {
+	if t.plannedTweet("quote", postURL, t.composeDetails(message[0])...) {
+		return func() {}
+	}
+
 	retweetButtonSelector := `//div[2]/div[@data-testid="retweet"]`
 
 	tweetTextareaSelector := `//div[2]/div/div/div/div/div/div[2]/div[2]/div/div/div/div[3]/div[2]/div[1]/div/div/div/div[1]/div[2]/div/div/div/div/div/div/div/div/div/div/div[1]/label/div[1]/div/div/div/div/div/div[2]/div[@data-testid="tweetTextarea_0"]`
@@ -281,13 +375,19 @@ func (t TweetHub) Quote(postURL string, message ...string) context.CancelFunc {
 
 		chromedp.WaitVisible(tweetTextareaSelector, chromedp.BySearch),
 		chromedp.SendKeys(tweetTextareaSelector, message[0], chromedp.BySearch),
+		attachMedia(`//div[@role="dialog"]`, t.media),
+		t.composeOptions(`//div[@role="dialog"]`),
 		chromedp.Click(tweetPostButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
 
 		chromedp.WaitVisible(alertSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "quote", Target: postURL, Message: message[0]}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to quote: %v\n", err)
+	} else if !t.scheduleAt.IsZero() {
+		fmt.Printf("Quote scheduled for %s\n", t.scheduleAt.Format(time.RFC1123))
 	} else {
 		fmt.Println("Quote successful")
 	}
@@ -297,6 +397,10 @@ func (t TweetHub) Quote(postURL string, message ...string) context.CancelFunc {
 
 // Follow performs the "follow" action on a specified Twitter username.
 func (t TweetHub) Follow(username string) context.CancelFunc {
+	if t.planned("follow", "@"+username) {
+		return func() {}
+	}
+
 	profileURL, _ := url.JoinPath(twitterURL, username)
 
 	followButtonSelector := fmt.Sprintf(`//div/div/div[2]/main/div/div/div/div/div/div[3]/div/div/div/div/div[1]/div[2]/div[2]/div[1]/div[@aria-label="Follow @%s"]`, username)
@@ -313,6 +417,8 @@ func (t TweetHub) Follow(username string) context.CancelFunc {
 		chromedp.WaitVisible(followingButtonSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "follow", Target: username}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to follow @%s: %v\n", username, err)
 	} else {
@@ -324,6 +430,10 @@ func (t TweetHub) Follow(username string) context.CancelFunc {
 
 // UnFollow performs the "unfollow" action on a specified Twitter username.
 func (t TweetHub) UnFollow(username string) context.CancelFunc {
+	if t.planned("unfollow", "@"+username) {
+		return func() {}
+	}
+
 	profileURL, _ := url.JoinPath(twitterURL, username)
 
 	followButtonSelector := fmt.Sprintf(`//div/div/div[2]/main/div/div/div/div/div/div[3]/div/div/div/div/div[1]/div[2]/div[2]/div[1]/div[@aria-label="Follow @%s"]`, username)
@@ -341,6 +451,8 @@ func (t TweetHub) UnFollow(username string) context.CancelFunc {
 		chromedp.WaitVisible(followButtonSelector, chromedp.BySearch),
 	)
 
+	t.record(ActionRecord{Action: "unfollow", Target: username}, err)
+
 	if err != nil {
 		fmt.Printf("Failed to unfollow @%s: %v\n", username, err)
 	} else {
@@ -349,3 +461,153 @@ func (t TweetHub) UnFollow(username string) context.CancelFunc {
 
 	return cancel
 }
+
+// Bookmark adds a given tweet URL to the bookmarks of the account.
+func (t TweetHub) Bookmark(tweetURL string) context.CancelFunc {
+	if t.plannedTweet("bookmark", tweetURL) {
+		return func() {}
+	}
+
+	bookmarkButtonSelector := `//div[@data-testid="bookmark"]`
+	removeBookmarkButtonSelector := `//div[@data-testid="removeBookmark"]`
+
+	ctx, cancel := t.Login()
+
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(tweetURL),
+
+		chromedp.WaitVisible(bookmarkButtonSelector, chromedp.BySearch),
+		chromedp.Click(bookmarkButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+		chromedp.WaitVisible(removeBookmarkButtonSelector, chromedp.BySearch),
+	)
+
+	t.record(ActionRecord{Action: "bookmark", Target: tweetURL}, err)
+
+	if err != nil {
+		fmt.Printf("Failed to bookmark the content at %s: %v\n", tweetURL, err)
+	} else {
+		fmt.Printf("Successfully bookmarked content at: %s\n", tweetURL)
+	}
+
+	return cancel
+}
+
+// UnBookmark removes a given tweet URL from the bookmarks of the account.
+func (t TweetHub) UnBookmark(tweetURL string) context.CancelFunc {
+	if t.plannedTweet("unbookmark", tweetURL) {
+		return func() {}
+	}
+
+	bookmarkButtonSelector := `//div[@data-testid="bookmark"]`
+	removeBookmarkButtonSelector := `//div[@data-testid="removeBookmark"]`
+
+	ctx, cancel := t.Login()
+
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(tweetURL),
+
+		chromedp.WaitVisible(removeBookmarkButtonSelector, chromedp.BySearch),
+		chromedp.Click(removeBookmarkButtonSelector, chromedp.BySearch, chromedp.NodeVisible),
+
+		chromedp.WaitVisible(bookmarkButtonSelector, chromedp.BySearch),
+	)
+
+	t.record(ActionRecord{Action: "unbookmark", Target: tweetURL}, err)
+
+	if err != nil {
+		fmt.Printf("Failed to unbookmark the content at %s: %v\n", tweetURL, err)
+	} else {
+		fmt.Printf("Successfully unbookmarked content at: %s\n", tweetURL)
+	}
+
+	return cancel
+}
+
+// Bookmarks returns up to limit tweets from the bookmarks of the account,
+// newest first. A limit of zero returns every bookmark.
+func (t TweetHub) Bookmarks(limit int) ([]Post, context.CancelFunc, error) {
+	bookmarksURL, _ := url.JoinPath(twitterURL, "i", "bookmarks")
+
+	var posts []Post
+
+	ctx, cancel := t.Login()
+
+	err := scrollTimeline(ctx, bookmarksURL, limit, func(post Post) bool {
+		posts = append(posts, post)
+		return true
+	})
+
+	return posts, cancel, err
+}
+
+// Pin pins a given tweet URL to the profile of the account and checks the
+// pinned badge on the profile afterwards.
+func (t TweetHub) Pin(tweetURL string) context.CancelFunc {
+	return t.pin(tweetURL, true)
+}
+
+// UnPin unpins a given tweet URL from the profile of the account and checks
+// that the profile no longer shows it as pinned.
+func (t TweetHub) UnPin(tweetURL string) context.CancelFunc {
+	return t.pin(tweetURL, false)
+}
+
+// pin opens the "More" menu of the tweet, picks the pin or unpin entry and
+// verifies the result on the profile of the account.
+func (t TweetHub) pin(tweetURL string, pinned bool) context.CancelFunc {
+	action, menuItem := "pin", "Pin to your profile"
+	if !pinned {
+		action, menuItem = "unpin", "Unpin from profile"
+	}
+
+	if t.plannedTweet(action, tweetURL) {
+		return func() {}
+	}
+
+	ref, err := ParseTweetRef(tweetURL)
+	if err != nil {
+		t.record(ActionRecord{Action: action, Target: tweetURL}, err)
+		fmt.Printf("Failed to %s tweet at URL %s: %v\n", action, tweetURL, err)
+		return func() {}
+	}
+
+	profileURL, _ := url.JoinPath(twitterURL, t.username)
+
+	moreSelector := `//div/div/div[2]/main/div/div/div/div/div/section/div/div/div[1]/div/div/article/div/div/div[2]/div[2]/div/div/div[2]/div/div/div/div/div[@aria-label="More"]`
+	menuItemSelector := fmt.Sprintf(`//div[@role="menu"]//div[@role="menuitem"][.//span[text()="%s"]]`, menuItem)
+	confirmSelector := `//div[@data-testid="confirmationSheetConfirm"]`
+	tweetSelector := `//article[@data-testid="tweet"]`
+	pinnedSelector := fmt.Sprintf(`//article[.//div[@data-testid="socialContext"][.//span[text()="Pinned"]]]//a[contains(@href, "/status/%s")]`, ref.ID)
+
+	verify := chromedp.WaitVisible(pinnedSelector, chromedp.BySearch)
+	if !pinned {
+		verify = chromedp.WaitNotPresent(pinnedSelector, chromedp.BySearch)
+	}
+
+	ctx, cancel := t.Login()
+
+	err = chromedp.Run(ctx,
+		chromedp.Navigate(ref.URL()),
+
+		chromedp.WaitVisible(moreSelector, chromedp.BySearch),
+		chromedp.Click(moreSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(menuItemSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.Click(confirmSelector, chromedp.BySearch, chromedp.NodeVisible),
+		chromedp.WaitNotPresent(confirmSelector, chromedp.BySearch),
+
+		chromedp.Navigate(profileURL),
+		chromedp.WaitVisible(tweetSelector, chromedp.BySearch),
+		verify,
+	)
+
+	t.record(ActionRecord{Action: action, Target: tweetURL}, err)
+
+	if err != nil {
+		fmt.Printf("Failed to %s tweet at URL %s: %v\n", action, tweetURL, err)
+	} else {
+		fmt.Printf("Successfully %sned tweet at: %s\n", action, tweetURL)
+	}
+
+	return cancel
+}
@@ -0,0 +1,61 @@
+package tweethub
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	statusIDPattern   = regexp.MustCompile(`^\d+$`)
+	statusPathPattern = regexp.MustCompile(`^/([A-Za-z0-9_]+|i/web)/status(?:es)?/(\d+)`)
+)
+
+// TweetRef identifies a tweet by its status ID and, when known, its author.
+type TweetRef struct {
+	ID       string
+	Username string
+}
+
+// ParseTweetRef parses a tweet URL on twitter.com or x.com, or a bare status ID.
+func ParseTweetRef(ref string) (TweetRef, error) {
+	ref = strings.TrimSpace(ref)
+
+	if statusIDPattern.MatchString(ref) {
+		return TweetRef{ID: ref}, nil
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return TweetRef{}, fmt.Errorf("invalid tweet reference %q: %v", ref, err)
+	}
+
+	switch strings.TrimPrefix(strings.TrimPrefix(u.Host, "www."), "mobile.") {
+	case "twitter.com", "x.com":
+	default:
+		return TweetRef{}, fmt.Errorf("invalid tweet reference %q: not a twitter.com or x.com URL", ref)
+	}
+
+	m := statusPathPattern.FindStringSubmatch(u.Path)
+	if m == nil {
+		return TweetRef{}, fmt.Errorf("invalid tweet reference %q: no status ID in URL", ref)
+	}
+
+	tweetRef := TweetRef{ID: m[2]}
+	if m[1] != "i/web" {
+		tweetRef.Username = m[1]
+	}
+
+	return tweetRef, nil
+}
+
+// URL returns the canonical URL of the tweet.
+func (r TweetRef) URL() string {
+	username := r.Username
+	if username == "" {
+		username = "i/web"
+	}
+
+	return fmt.Sprintf("%s/%s/status/%s", twitterURL, username, r.ID)
+}
@@ -0,0 +1,70 @@
+package tweethub
+
+import "testing"
+
+func TestParseTweetRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    TweetRef
+		wantURL string
+		wantErr bool
+	}{
+		{
+			ref:     "1234567890",
+			want:    TweetRef{ID: "1234567890"},
+			wantURL: "https://twitter.com/i/web/status/1234567890",
+		},
+		{
+			ref:     " 1234567890\n",
+			want:    TweetRef{ID: "1234567890"},
+			wantURL: "https://twitter.com/i/web/status/1234567890",
+		},
+		{
+			ref:     "https://twitter.com/alice/status/1234567890",
+			want:    TweetRef{ID: "1234567890", Username: "alice"},
+			wantURL: "https://twitter.com/alice/status/1234567890",
+		},
+		{
+			ref:     "https://x.com/alice/status/1234567890?s=20",
+			want:    TweetRef{ID: "1234567890", Username: "alice"},
+			wantURL: "https://twitter.com/alice/status/1234567890",
+		},
+		{
+			ref:     "https://mobile.twitter.com/alice/statuses/1234567890/photo/1",
+			want:    TweetRef{ID: "1234567890", Username: "alice"},
+			wantURL: "https://twitter.com/alice/status/1234567890",
+		},
+		{
+			ref:     "https://www.x.com/i/web/status/1234567890",
+			want:    TweetRef{ID: "1234567890"},
+			wantURL: "https://twitter.com/i/web/status/1234567890",
+		},
+		{ref: "", wantErr: true},
+		{ref: "12a4", wantErr: true},
+		{ref: "https://example.com/alice/status/1234567890", wantErr: true},
+		{ref: "https://twitter.com/alice", wantErr: true},
+		{ref: "https://twitter.com/alice/status/abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ParseTweetRef(tt.ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseTweetRef(%q) = %+v, want an error", tt.ref, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTweetRef(%q) error = %v", tt.ref, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseTweetRef(%q) = %+v, want %+v", tt.ref, got, tt.want)
+			}
+			if got.URL() != tt.wantURL {
+				t.Errorf("URL() = %q, want %q", got.URL(), tt.wantURL)
+			}
+		})
+	}
+}